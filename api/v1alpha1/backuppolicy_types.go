@@ -0,0 +1,78 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreFromBackupAnnotation names a backup ConfigMap, in the same namespace, whose ManagedOCS
+// spec is restored into a new ManagedOCS resource carrying the annotation
+const RestoreFromBackupAnnotation = "ocs.openshift.io/restore-from-backup"
+
+// BackupPolicySpec defines the schedule of the backups of a ManagedOCS resource
+type BackupPolicySpec struct {
+	// ManagedOCSRef references the ManagedOCS resource, in the same namespace, whose spec
+	// and StorageCluster spec are backed up
+	ManagedOCSRef corev1.LocalObjectReference `json:"managedOCSRef"`
+
+	// Schedule is a standard cron schedule, evaluated in UTC, at which the backups are taken
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// RetentionCount is the number of backups kept, older backups are deleted. Defaults to 7
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RetentionCount *int32 `json:"retentionCount,omitempty"`
+}
+
+// BackupPolicyStatus defines the observed state of BackupPolicy
+type BackupPolicyStatus struct {
+	// LastBackupTime is the time the last backup was taken
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// LastBackupName is the name of the ConfigMap holding the last backup
+	// +optional
+	LastBackupName string `json:"lastBackupName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// BackupPolicy is the Schema for the backuppolicies API
+type BackupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupPolicySpec   `json:"spec,omitempty"`
+	Status BackupPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupPolicyList contains a list of BackupPolicy
+type BackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupPolicy{}, &BackupPolicyList{})
+}
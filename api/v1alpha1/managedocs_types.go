@@ -17,7 +17,13 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	rook "github.com/rook/rook/pkg/apis/rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ReconcileStrategy represent the action the deployer should take whenever a recncile event occures
@@ -31,11 +37,350 @@ const (
 	// ReconcileStrategyStrict is used to indicate that the deployer should enforce
 	// storage clsuter based on a predefined spec
 	ReconcileStrategyStrict ReconcileStrategy = "strict"
+
+	// ReconcileStrategyForce is used to indicate that the deployer should merge
+	// the predefined spec into the storage cluster spec, field by field, instead
+	// of replacing it
+	ReconcileStrategyForce ReconcileStrategy = "force"
 )
 
+// ReclaimPolicy describes what happens to the managed StorageClusters when the ManagedOCS
+// resource is deleted
+type ReclaimPolicy string
+
+const (
+	// ReclaimPolicyRetain is used to indicate that the StorageClusters, and their volumes,
+	// are kept and released from the ManagedOCS resource
+	ReclaimPolicyRetain ReclaimPolicy = "Retain"
+
+	// ReclaimPolicyDelete is used to indicate that the StorageClusters, and transitively
+	// their volumes, are deleted
+	ReclaimPolicyDelete ReclaimPolicy = "Delete"
+)
+
+// DefaultStorageClusterName is the name of the StorageCluster managed by ManagedOCS resources
+// that do not set one
+const DefaultStorageClusterName = "ocs-storagecluster"
+
+// DefaultCapacityAlertThreshold is the capacity alert threshold, in percent, of ManagedOCS
+// resources that do not set one
+const DefaultCapacityAlertThreshold int32 = 80
+
+// ManagedStorageCluster defines one of the StorageClusters managed by the deployer
+type ManagedStorageCluster struct {
+	// Name is the name of the StorageCluster
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// StorageClusterTemplate overrides spec.storageClusterTemplate for this StorageCluster
+	// +optional
+	StorageClusterTemplate *corev1.LocalObjectReference `json:"storageClusterTemplate,omitempty"`
+}
+
 // ManagedOCSSpec defines the desired state of ManagedOCS
 type ManagedOCSSpec struct {
+	// StorageClusterName is the name of the StorageCluster managed by the deployer, in the
+	// same namespace. Defaults to ocs-storagecluster, it cannot be changed once set
+	// +optional
+	StorageClusterName string `json:"storageClusterName,omitempty"`
+
+	// ReconcileStrategy is the action the deployer takes on the StorageCluster whenever
+	// a reconcile event occurs. Defaults to strict
+	// +kubebuilder:validation:Enum=none;strict;force
+	// +optional
 	ReconcileStrategy ReconcileStrategy `json:"reconcileStrategy,omitempty"`
+
+	// ReclaimPolicy is the action the deployer takes on the StorageClusters when the
+	// ManagedOCS resource is deleted. Defaults to Retain
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	ReclaimPolicy ReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// LogLevel is the minimum level of the messages logged by the deployer. It takes
+	// precedence over the log level of the OperatorConfig
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// DebugMode logs the verbose reconcile messages of this ManagedOCS resource, without
+	// changing the log level of the deployer
+	// +optional
+	DebugMode bool `json:"debugMode,omitempty"`
+
+	// StorageClusterTemplate references a ConfigMap, in the same namespace, holding the
+	// desired storage cluster spec under the storagecluster.yaml key. The spec is rendered
+	// as a Go template, with the ManagedOCS Namespace and Spec as data. Changes to the
+	// ConfigMap are only watched when it is labeled ocs.openshift.io/template=true. It only
+	// applies to the strict reconcile strategy, the built-in template is used otherwise or
+	// when it is not set
+	// +optional
+	StorageClusterTemplate *corev1.LocalObjectReference `json:"storageClusterTemplate,omitempty"`
+
+	// StorageClusters lists the StorageClusters managed by the deployer, in the same
+	// namespace. The first StorageCluster is the primary one, whose status is reported.
+	// When empty, a single StorageCluster named after StorageClusterName is managed
+	// +optional
+	StorageClusters []ManagedStorageCluster `json:"storageClusters,omitempty"`
+
+	// AdoptExistingCluster allows the deployer to take over a StorageCluster that already
+	// exists and is not owned by a ManagedOCS resource. The adopted spec is kept, the
+	// reconcile strategy is set to none on adoption
+	// +optional
+	AdoptExistingCluster bool `json:"adoptExistingCluster,omitempty"`
+
+	// StorageDeviceSetCount overrides the count of all the storage device sets of the
+	// desired StorageCluster. The upper bound is enforced by the validating webhook, which
+	// also rejects decreases. Increases are applied once the storage nodes can host them
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StorageDeviceSetCount *int32 `json:"storageDeviceSetCount,omitempty"`
+
+	// AutoSizing derives the count of the storage device sets of the desired StorageCluster
+	// from the devices of the storage nodes. The count is never decreased, and is ignored
+	// when StorageDeviceSetCount is set
+	// +optional
+	AutoSizing bool `json:"autoSizing,omitempty"`
+
+	// StorageProfile selects a pre-canned size of the storage device sets of the desired
+	// StorageCluster. A profile other than custom overrides the count and the size of the
+	// storage device sets, it cannot be combined with StorageDeviceSetCount or AutoSizing.
+	// The count is never decreased. Defaults to custom, which applies the spec fields as-is
+	// +optional
+	StorageProfile StorageProfile `json:"storageProfile,omitempty"`
+
+	// AllowedNamespaces restricts the namespaces that can create PVCs against the StorageClasses
+	// of the managed storage clusters. A ResourceQuota allowing no such PVC is created in every
+	// other namespace, except the namespace of the ManagedOCS. When empty, all the namespaces
+	// are allowed
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// FullReconcileIntervalMinutes is the interval at which the desired state is applied
+	// again, even in the absence of watch events. Defaults to the interval set in the
+	// OperatorConfig, or to 60 minutes
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	// +optional
+	FullReconcileIntervalMinutes *int32 `json:"fullReconcileIntervalMinutes,omitempty"`
+
+	// NodeSelector restricts the storage device sets of the desired StorageCluster to the
+	// nodes carrying all the given labels. It replaces the node affinity of the template
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// EncryptionConfig enables the encryption of the OSDs of the desired StorageCluster.
+	// Encryption cannot be disabled once enabled
+	// +optional
+	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
+
+	// ManagedResources is merged on top of the managed resources of the desired
+	// StorageCluster, only the fields that are set override the template
+	// +optional
+	ManagedResources *ocsv1.ManagedResourcesSpec `json:"managedResources,omitempty"`
+
+	// MinOCSVersion is the lowest installed OCS operator version, inclusive, the
+	// StorageCluster is reconciled with
+	// +optional
+	MinOCSVersion string `json:"minOCSVersion,omitempty"`
+
+	// MaxOCSVersion is the highest installed OCS operator version, inclusive, the
+	// StorageCluster is reconciled with
+	// +optional
+	MaxOCSVersion string `json:"maxOCSVersion,omitempty"`
+
+	// Tolerations are added to the placement of all the storage device sets of the desired
+	// StorageCluster, so they can be scheduled on tainted storage nodes. Effects are
+	// validated by the validating webhook
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// AffinitySpec sets the pod anti-affinity of all the storage device sets of the desired
+	// StorageCluster, so the OSDs are spread across failure domains. Only the pod
+	// anti-affinity is applied, the node affinity is set through nodeSelector. When not set,
+	// the OSDs are preferably spread across zones
+	// +optional
+	AffinitySpec *corev1.Affinity `json:"affinitySpec,omitempty"`
+
+	// ResourceRequirements overrides the resource requirements of the StorageCluster
+	// components, keyed by component name. The osd entry applies to all the storage device
+	// sets, other entries apply to the StorageCluster resources of the same name
+	// +optional
+	ResourceRequirements map[string]corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// NetworkSpec replaces the network settings of the desired StorageCluster, e.g. to use a
+	// dedicated multus storage network. The template settings are used when not set
+	// +optional
+	NetworkSpec *rook.NetworkSpec `json:"networkSpec,omitempty"`
+
+	// NetworkIsolation restricts the ingress traffic of the Ceph mon, osd, mds and mgr pods to
+	// the pods in the same namespace, where the Ceph daemons, the CSI plugins and the OCS
+	// operators run, through NetworkPolicies owned by the ManagedOCS. The mgr metrics port
+	// stays reachable from all namespaces
+	// +optional
+	NetworkIsolation bool `json:"networkIsolation,omitempty"`
+
+	// MaintenanceWindow suspends the reconciliation of the ManagedOCS during a planned
+	// outage, so manual interventions are not reverted. The reconcile resumes once it ends
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Paused suspends the reconciliation of the ManagedOCS, including its deletion, until it
+	// is unset, in which case a full reconcile is run. Unlike the reconcile pause annotation,
+	// meant for emergencies, it is part of the desired state, e.g. managed through GitOps
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// MaintenanceMode prepares the deployer for an upgrade of the operator. With DrainAndPause,
+	// the reconcile in progress is completed, the readiness server reports every readiness
+	// condition as not ready, and nothing is reconciled until it is unset, in which case a full
+	// reconcile is run
+	// +optional
+	MaintenanceMode MaintenanceMode `json:"maintenanceMode,omitempty"`
+
+	// CephClusterSpec is a StorageCluster spec fragment merged on top of the spec of the
+	// desired StorageCluster, once the template and the other fields are applied. It sets
+	// the StorageCluster fields that have no ManagedOCS field, e.g. externalStorage
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	CephClusterSpec *runtime.RawExtension `json:"cephClusterSpec,omitempty"`
+
+	// CustomCephConf sets raw Ceph configuration options through the Rook configuration
+	// override, keyed in the section/option format, e.g. osd/osd_scrub_max_interval. Any Ceph
+	// option can be set, including the ones weakening the authentication of the Ceph daemons
+	// or the durability of the data, so it is only applied when AllowCustomCephConf is set.
+	// Options removed from the spec are removed from the override, and take effect once the
+	// Ceph daemons restart
+	// +optional
+	CustomCephConf map[string]string `json:"customCephConf,omitempty"`
+
+	// AllowCustomCephConf acknowledges that CustomCephConf can set any Ceph option, it is
+	// required to apply CustomCephConf
+	// +optional
+	AllowCustomCephConf bool `json:"allowCustomCephConf,omitempty"`
+
+	// CapacityAlertThreshold is the utilization of the raw capacity of the StorageCluster, in
+	// percent, above which the CapacityWarning condition is set. Defaults to 80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CapacityAlertThreshold *int32 `json:"capacityAlertThreshold,omitempty"`
+
+	// RecoveryCheckInterval is the interval, in seconds, at which a StorageCluster whose update
+	// is deferred while Ceph is recovering is checked again. Defaults to 60
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RecoveryCheckInterval *int32 `json:"recoveryCheckInterval,omitempty"`
+
+	// AllowTemplateUpgrade allows the deployer to apply a storage cluster template whose
+	// version differs from the one applied to the primary StorageCluster. Without it, the
+	// StorageCluster is left untouched until the upgrade is allowed
+	// +optional
+	AllowTemplateUpgrade bool `json:"allowTemplateUpgrade,omitempty"`
+
+	// ExternalCephSecretRef references a Secret, in the same namespace, holding the
+	// credentials of an external Ceph cluster under the userID, userKey, adminID, adminKey
+	// and monData keys. When set, the desired StorageCluster enables external storage
+	// +optional
+	ExternalCephSecretRef *corev1.LocalObjectReference `json:"externalCephSecretRef,omitempty"`
+
+	// StorageClusterAnnotations are set on the StorageClusters, overriding the annotations
+	// already set with the same keys, e.g. to enable OCS features activated by annotation.
+	// Annotations removed from the ManagedOCS spec are left on the StorageClusters. They are
+	// not applied under the none reconcile strategy
+	// +optional
+	StorageClusterAnnotations map[string]string `json:"storageClusterAnnotations,omitempty"`
+
+	// StorageClusterLabels are set on the StorageClusters, overriding the labels already set
+	// with the same keys, e.g. for cost attribution. Labels prefixed with kubernetes.io/ or
+	// openshift.io/ are reserved. Labels removed from the ManagedOCS spec are left on the
+	// StorageClusters. They are not applied under the none reconcile strategy
+	// +optional
+	StorageClusterLabels map[string]string `json:"storageClusterLabels,omitempty"`
+
+	// MigrateFromExisting migrates a StorageCluster deployed without the deployer, e.g. by a
+	// self-managed OCS installation. Its spec is snapshotted into the ConfigMap named after
+	// the ManagedOCS with a -migration-snapshot suffix, which becomes the storage cluster
+	// template when none is set, and the StorageCluster is adopted under the none reconcile
+	// strategy. The reconcile strategy is switched to strict once the StorageCluster stayed
+	// available for the migration soak duration
+	// +optional
+	MigrateFromExisting bool `json:"migrateFromExisting,omitempty"`
+
+	// MigrationSoakDuration is how long a migrated StorageCluster must stay available before
+	// the reconcile strategy is switched to strict. Defaults to 24h
+	// +optional
+	MigrationSoakDuration *metav1.Duration `json:"migrationSoakDuration,omitempty"`
+
+	// ImageOverrides replaces the container images of the OCS components, keyed by component
+	// name, e.g. to pull them from a local registry in air-gapped environments. The ceph,
+	// rook, noobaa and noobaa-db components are supported, and are applied to the
+	// deployments of the OCS CSV. Overrides removed from the spec are left on the CSV until
+	// the next OCS upgrade
+	// +optional
+	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
+
+	// MonitoringSpec configures the scraping of the metrics of the OCS components by the
+	// Prometheus deployed by the deployer
+	// +optional
+	MonitoringSpec *MonitoringSpec `json:"monitoringSpec,omitempty"`
+
+	// AlertingSpec configures the alerting rules of the OCS storage evaluated by the Prometheus
+	// deployed by the deployer
+	// +optional
+	AlertingSpec *AlertingSpec `json:"alertingSpec,omitempty"`
+}
+
+// AlertingSpec defines the alerting rules of the OCS storage
+type AlertingSpec struct {
+	// Enabled creates a PrometheusRule, owned by the ManagedOCS, holding the default storage
+	// alerting rules merged with the custom rules. It is deleted once it is disabled
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CustomRules are the names of the ConfigMaps, in the namespace of the ManagedOCS, holding
+	// a PrometheusRule in their prometheusrule.yaml entry. The rule groups of the PrometheusRules
+	// are appended to the default storage alerting rules
+	// +optional
+	CustomRules []string `json:"customRules,omitempty"`
+}
+
+// MonitoringSpec defines the scraping of the metrics of the OCS components
+type MonitoringSpec struct {
+	// Enabled creates ServiceMonitors, owned by the ManagedOCS, scraping the metrics of the
+	// Ceph mgr and of the OCS metrics exporter. They are deleted once it is disabled
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ScrapeIntervalSeconds is the interval at which the metrics are scraped. Defaults to 30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ScrapeIntervalSeconds int32 `json:"scrapeIntervalSeconds,omitempty"`
+}
+
+// DefaultScrapeIntervalSeconds is the scrape interval of the metrics of the OCS components when
+// the monitoring spec does not set one
+const DefaultScrapeIntervalSeconds int32 = 30
+
+// MaintenanceWindow is a period of time during which the deployer leaves the managed
+// resources untouched
+type MaintenanceWindow struct {
+	// Start is the RFC3339 time at which the maintenance window opens
+	Start metav1.Time `json:"start"`
+
+	// End is the RFC3339 time at which the maintenance window closes, it must be after Start
+	End metav1.Time `json:"end"`
+}
+
+// EncryptionConfig defines the OSD encryption settings of the StorageCluster
+type EncryptionConfig struct {
+	// Enabled turns on the encryption of the OSDs
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// KMSEndpoint is the URL of the key management service holding the encryption keys.
+	// It is required when encryption is enabled
+	// +optional
+	KMSEndpoint string `json:"kmsEndpoint,omitempty"`
 }
 
 type ComponentState string
@@ -47,6 +392,100 @@ const (
 	ComponentUnknown  ComponentState = "Unknown"
 )
 
+// ManagedOCSPhase summarizes the state of the managed StorageCluster
+type ManagedOCSPhase string
+
+const (
+	// PhaseInitializing is used while the StorageCluster is being created or is progressing
+	PhaseInitializing ManagedOCSPhase = "Initializing"
+
+	// PhaseReady is used once the StorageCluster reports itself as ready
+	PhaseReady ManagedOCSPhase = "Ready"
+
+	// PhaseError is used when the StorageCluster reports an error
+	PhaseError ManagedOCSPhase = "Error"
+
+	// PhaseUnknown is used when the StorageCluster phase cannot be determined
+	PhaseUnknown ManagedOCSPhase = "Unknown"
+)
+
+// MigrationPhase is the state of the migration of a StorageCluster deployed without the deployer
+// +kubebuilder:validation:Enum=Adopting;Soaking;Completed
+type MigrationPhase string
+
+const (
+	// MigrationPhaseAdopting is used once the spec of the StorageCluster is snapshotted, until
+	// the StorageCluster is adopted
+	MigrationPhaseAdopting MigrationPhase = "Adopting"
+
+	// MigrationPhaseSoaking is used once the StorageCluster is adopted, until it stayed
+	// available for the migration soak duration
+	MigrationPhaseSoaking MigrationPhase = "Soaking"
+
+	// MigrationPhaseCompleted is used once the reconcile strategy is switched to strict
+	MigrationPhaseCompleted MigrationPhase = "Completed"
+)
+
+// MaintenanceMode is the preparation of the deployer for an upgrade of the operator
+// +kubebuilder:validation:Enum=DrainAndPause
+type MaintenanceMode string
+
+// MaintenanceModeDrainAndPause completes the reconcile in progress and suspends the
+// reconciliation, reporting the deployment as not ready
+const MaintenanceModeDrainAndPause MaintenanceMode = "DrainAndPause"
+
+// MaintenanceModeReason is the reason of the Paused condition, and of the readiness conditions,
+// while spec.maintenanceMode is set
+const MaintenanceModeReason = "MaintenanceMode"
+
+// StorageProfile is a pre-canned size of the storage device sets of the StorageCluster
+// +kubebuilder:validation:Enum=small;medium;large;custom
+type StorageProfile string
+
+const (
+	// StorageProfileSmall deploys 3 nodes with 1Ti each
+	StorageProfileSmall StorageProfile = "small"
+
+	// StorageProfileMedium deploys 3 nodes with 4Ti each
+	StorageProfileMedium StorageProfile = "medium"
+
+	// StorageProfileLarge deploys 9 nodes with 8Ti each
+	StorageProfileLarge StorageProfile = "large"
+
+	// StorageProfileCustom applies the count and the size of the storage device sets set by
+	// the template and the ManagedOCS spec
+	StorageProfileCustom StorageProfile = "custom"
+)
+
+// Components whose container images can be overridden through spec.imageOverrides
+const (
+	ImageOverrideCeph     = "ceph"
+	ImageOverrideRook     = "rook"
+	ImageOverrideNooBaa   = "noobaa"
+	ImageOverrideNooBaaDB = "noobaa-db"
+)
+
+// DefaultMigrationSoakDuration is the migration soak duration of ManagedOCS resources that do
+// not set one
+const DefaultMigrationSoakDuration = 24 * time.Hour
+
+// ScalingPhase is the state of a scale-up of the storage device sets of the StorageCluster
+// +kubebuilder:validation:Enum=Pending;Scaling;Ready
+type ScalingPhase string
+
+const (
+	// ScalingPhasePending is used while the storage nodes cannot host the requested storage
+	// device set count, the StorageCluster keeps its current count
+	ScalingPhasePending ScalingPhase = "Pending"
+
+	// ScalingPhaseScaling is used once the requested count is applied to the StorageCluster,
+	// until the StorageCluster is ready again
+	ScalingPhaseScaling ScalingPhase = "Scaling"
+
+	// ScalingPhaseReady is used once the StorageCluster is ready with the requested count
+	ScalingPhaseReady ScalingPhase = "Ready"
+)
+
 type ComponentStatus struct {
 	State ComponentState `json:"state"`
 }
@@ -57,15 +496,201 @@ type ComponentStatusMap struct {
 	Alertmanager   ComponentStatus `json:"alertmanager"`
 }
 
+// Condition types mirrored from the StorageCluster status
+const (
+	ConditionStorageClusterAvailable   = "ocs.openshift.io/Available"
+	ConditionStorageClusterProgressing = "ocs.openshift.io/Progressing"
+	ConditionStorageClusterDegraded    = "ocs.openshift.io/Degraded"
+)
+
+// ConditionOSDDegraded reports whether the StorageCluster is in an error phase or degraded,
+// usually because some of its OSDs are down
+const ConditionOSDDegraded = "OSDDegraded"
+
+// ConditionSpecDrift is set while the reconcile strategy is none, and reports whether the
+// StorageCluster spec differs from the last spec applied by the deployer
+const ConditionSpecDrift = "SpecDrift"
+
+// ConditionPreflightFailed reports whether the cluster is missing prerequisites of the
+// StorageCluster, in which case the StorageCluster is not created or updated
+const ConditionPreflightFailed = "PreflightFailed"
+
+// ConditionVersionMismatch reports whether the installed OCS operator version is outside of
+// the range set in the ManagedOCS spec, in which case the StorageCluster is not reconciled
+const ConditionVersionMismatch = "VersionMismatch"
+
+// ConditionCSVFailed is set when the installation of the OCS CSV failed, in which case the
+// StorageCluster is not reconciled
+const ConditionCSVFailed = "CSVFailed"
+
+// ConditionSubscriptionFailed is set when the OLM Subscription of the OCS operator failed to
+// upgrade, in which case the StorageCluster is not reconciled
+const ConditionSubscriptionFailed = "SubscriptionFailed"
+
+// ConditionStorageClassesReady reports whether the StorageClasses provisioned by the
+// StorageCluster exist once the StorageCluster is available
+const ConditionStorageClassesReady = "StorageClassesReady"
+
+// ConditionStorageClassMissing is set to True when the StorageClasses provisioned by the
+// StorageCluster are still missing once the StorageClass timeout has elapsed
+const ConditionStorageClassMissing = "StorageClassMissing"
+
+// ConditionReconcileFailed is set to True when the last reconcile failed, with the reason of
+// the failing reconcile phase
+const ConditionReconcileFailed = "ReconcileFailed"
+
+// ConditionMaintenanceActive is set to True while the maintenance window of the ManagedOCS
+// is open, in which case nothing is reconciled
+const ConditionMaintenanceActive = "MaintenanceActive"
+
+// ConditionPaused is set to True while spec.paused or spec.maintenanceMode is set, in which case
+// nothing is reconciled
+const ConditionPaused = "Paused"
+
+// ConditionCapacityWarning is set to True while the utilization of the raw capacity of the
+// StorageCluster exceeds the capacity alert threshold
+const ConditionCapacityWarning = "CapacityWarning"
+
+// CapacityCriticalReason is the reason of the CapacityWarning condition while the utilization
+// exceeds 95 percent, in which case the deployer is reported as not ready
+const CapacityCriticalReason = "CapacityCritical"
+
+// ConditionUpdateDeferred is set to True while the update of a StorageCluster is deferred
+// because Ceph is recovering, e.g. backfilling or scrubbing
+const ConditionUpdateDeferred = "UpdateDeferred"
+
+// ConditionAdoptionRefused is set to True while a managed StorageCluster exists and is not
+// owned by a ManagedOCS resource, in which case it is left alone until spec.adoptExistingCluster
+// is set
+const ConditionAdoptionRefused = "AdoptionRefused"
+
+// ConditionBackpressure is set to True while the utilization of the raw capacity of the
+// StorageCluster exceeds 85 percent, or while the StorageCluster is degraded, in which case
+// new PVCs of the managed StorageClasses are rejected
+const ConditionBackpressure = "Backpressure"
+
+// ConditionRecord is a condition superseded by a reconcile
+type ConditionRecord struct {
+	// Timestamp is the last transition time of the superseded condition
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Type is the type of the superseded condition
+	Type string `json:"type"`
+
+	// Status is the status of the superseded condition
+	Status metav1.ConditionStatus `json:"status"`
+
+	// Reason is the reason of the superseded condition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
 // ManagedOCSStatus defines the observed state of ManagedOCS
 type ManagedOCSStatus struct {
 	ReconcileStrategy ReconcileStrategy  `json:"reconcileStrategy,omitempty"`
 	Components        ComponentStatusMap `json:"components"`
+
+	// Phase mirrors the phase of the managed StorageCluster
+	// +optional
+	Phase ManagedOCSPhase `json:"phase,omitempty"`
+
+	// AppliedTemplateVersion is the version of the storage cluster template last applied to
+	// the primary StorageCluster
+	// +optional
+	AppliedTemplateVersion string `json:"appliedTemplateVersion,omitempty"`
+
+	// Conditions represent the latest available observations of the managed components
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ConditionHistory records the conditions superseded by a reconcile, oldest first, capped
+	// at the last 20 records
+	// +optional
+	ConditionHistory []ConditionRecord `json:"conditionHistory,omitempty"`
+
+	// RetryAfterSeconds is the backoff, in seconds, before the deployer retries a reconcile
+	// that failed with a transient error. It is reset once a reconcile succeeds
+	// +optional
+	RetryAfterSeconds int64 `json:"retryAfterSeconds,omitempty"`
+
+	// ErrorMessage is the error of the last reconcile, it is cleared once a reconcile succeeds
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// ScalingPhase is the state of the last scale-up of the storage device sets requested
+	// through StorageDeviceSetCount
+	// +optional
+	ScalingPhase ScalingPhase `json:"scalingPhase,omitempty"`
+
+	// MigrationPhase is the state of the migration requested through MigrateFromExisting
+	// +optional
+	MigrationPhase MigrationPhase `json:"migrationPhase,omitempty"`
+
+	// MigrationSoakStartTime is the time since which the migrated StorageCluster is available,
+	// it is reset whenever the StorageCluster becomes unavailable during the soak
+	// +optional
+	MigrationSoakStartTime *metav1.Time `json:"migrationSoakStartTime,omitempty"`
+
+	// LastReconcileTime is the time the desired state was last applied by a successful
+	// reconcile. Unlike LastSyncTime, it excludes the reconciles of a paused ManagedOCS and
+	// the reconciles skipped during maintenance
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// ReconciledByVersion is the build version of the operator that applied the desired state
+	// at LastReconcileTime, set from the OPERATOR_VERSION environment variable of the deployer
+	// +optional
+	ReconciledByVersion string `json:"reconciledByVersion,omitempty"`
+
+	// LastSyncTime is the time of the end of the last successful reconcile, including the
+	// reconciles of a paused ManagedOCS. It is set on every resync of a healthy controller
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the ManagedOCS spec last reconciled successfully
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// StorageClusterSpecHash is the SHA-256 hex digest of the last StorageCluster spec
+	// applied by the deployer
+	// +optional
+	StorageClusterSpecHash string `json:"storageClusterSpecHash,omitempty"`
+
+	// StorageClusterRef references the StorageCluster, in the same namespace, created or
+	// adopted by the deployer
+	// +optional
+	StorageClusterRef *corev1.LocalObjectReference `json:"storageClusterRef,omitempty"`
+
+	// TotalCapacityBytes is the raw capacity of the StorageCluster, aggregated from the
+	// size, count and replica of its storage device sets
+	// +optional
+	TotalCapacityBytes int64 `json:"totalCapacityBytes,omitempty"`
+
+	// UsedCapacityBytes is the raw capacity in use, as reported by Ceph in the status of the
+	// CephCluster of the StorageCluster
+	// +optional
+	UsedCapacityBytes int64 `json:"usedCapacityBytes,omitempty"`
+
+	// TotalOSDCount is the number of OSD pods of the StorageCluster
+	// +optional
+	TotalOSDCount int32 `json:"totalOSDCount,omitempty"`
+
+	// ReadyOSDCount is the number of OSD pods of the StorageCluster that are ready
+	// +optional
+	ReadyOSDCount int32 `json:"readyOSDCount,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=mocs
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="STRATEGY",type="string",JSONPath=".status.reconcileStrategy"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"ocs.openshift.io/Available\")].status"
+// +kubebuilder:printcolumn:name="CAPACITY",type="integer",JSONPath=".status.totalCapacityBytes"
+// +kubebuilder:printcolumn:name="LAST_SYNC",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ERROR",type="string",JSONPath=".status.errorMessage",priority=1
 
 // ManagedOCS is the Schema for the managedocs API
 type ManagedOCS struct {
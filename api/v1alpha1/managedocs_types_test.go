@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ManagedOCS deep copied", func() {
+	newManagedOCS := func() *ManagedOCS {
+		count := int32(3)
+		lastReconcileTime := metav1.NewTime(time.Unix(1600000000, 0))
+		return &ManagedOCS{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "managedocs",
+				Namespace: "openshift-storage",
+			},
+			Spec: ManagedOCSSpec{
+				ReconcileStrategy:     ReconcileStrategyStrict,
+				StorageDeviceSetCount: &count,
+			},
+			Status: ManagedOCSStatus{
+				Conditions: []metav1.Condition{{
+					Type:               ConditionSpecDrift,
+					Status:             metav1.ConditionFalse,
+					Reason:             "SpecInSync",
+					LastTransitionTime: lastReconcileTime,
+				}},
+				LastReconcileTime: &lastReconcileTime,
+			},
+		}
+	}
+
+	When("the conditions of the copied are modified", func() {
+		It("should leave the conditions of the original unchanged", func() {
+			original := newManagedOCS()
+			copied := original.DeepCopy()
+
+			copied.Status.Conditions[0].Status = metav1.ConditionTrue
+			copied.Status.Conditions[0].Reason = "SpecModified"
+			copied.Status.Conditions = append(copied.Status.Conditions, metav1.Condition{
+				Type:   ConditionPreflightFailed,
+				Status: metav1.ConditionFalse,
+			})
+
+			Expect(original).To(Equal(newManagedOCS()))
+		})
+	})
+
+	When("the pointer fields of the copied are modified", func() {
+		It("should leave the original unchanged", func() {
+			original := newManagedOCS()
+			copied := original.DeepCopy()
+
+			*copied.Spec.StorageDeviceSetCount = 5
+			copied.Status.LastReconcileTime.Time = time.Now()
+
+			Expect(original).To(Equal(newManagedOCS()))
+		})
+	})
+})
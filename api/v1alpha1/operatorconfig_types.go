@@ -0,0 +1,72 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfigSpec defines the operational parameters of the deployer
+type OperatorConfigSpec struct {
+	// LogLevel is the minimum level of the messages logged by the deployer
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// ReconcileInterval is the interval at which the ManagedOCS resource is reconciled
+	// in the absence of watch events
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// ReadinessCheckInterval is the interval at which the status of managed components
+	// is checked again while they are not ready
+	// +optional
+	ReadinessCheckInterval *metav1.Duration `json:"readinessCheckInterval,omitempty"`
+
+	// StorageClusterPhaseTimeout is the time the StorageCluster can stay unavailable
+	// before the deployer reports it as stuck
+	// +optional
+	StorageClusterPhaseTimeout *metav1.Duration `json:"storageClusterPhaseTimeout,omitempty"`
+
+	// StorageClassTimeout is the time the StorageClasses of an available StorageCluster can
+	// be missing before the deployer reports them as missing
+	// +optional
+	StorageClassTimeout *metav1.Duration `json:"storageClassTimeout,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperatorConfig is the Schema for the operatorconfigs API
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OperatorConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperatorConfigList contains a list of OperatorConfig
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfig{}, &OperatorConfigList{})
+}
@@ -0,0 +1,58 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TuningPolicySpec defines Ceph tuning applied to the StorageCluster of a ManagedOCS resource
+type TuningPolicySpec struct {
+	// ManagedOCSRef references the ManagedOCS resource, in the same namespace, whose
+	// StorageCluster is tuned
+	ManagedOCSRef corev1.LocalObjectReference `json:"managedOCSRef"`
+
+	// CephConfig holds Ceph configuration options, applied to the global section of the
+	// Ceph configuration. Policies are applied in name order, the last policy setting an
+	// option wins
+	// +optional
+	CephConfig map[string]string `json:"cephConfig,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TuningPolicy is the Schema for the tuningpolicies API
+type TuningPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TuningPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TuningPolicyList contains a list of TuningPolicy
+type TuningPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TuningPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TuningPolicy{}, &TuningPolicyList{})
+}
@@ -0,0 +1,43 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// ConvertTo converts this ManagedOCS to the hub version (v1alpha1)
+func (src *ManagedOCS) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ManagedOCS)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	src.Spec.DeepCopyInto(&dst.Spec)
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *ManagedOCS) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ManagedOCS)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	src.Spec.DeepCopyInto(&dst.Spec)
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("ManagedOCS conversion", func() {
+	newHub := func() *v1alpha1.ManagedOCS {
+		count := int32(3)
+		return &v1alpha1.ManagedOCS{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "managedocs",
+				Namespace: "openshift-storage",
+			},
+			Spec: v1alpha1.ManagedOCSSpec{
+				ReconcileStrategy:     v1alpha1.ReconcileStrategyStrict,
+				StorageDeviceSetCount: &count,
+				NodeSelector:          map[string]string{"node-role.kubernetes.io/worker": ""},
+			},
+			Status: v1alpha1.ManagedOCSStatus{
+				Phase: v1alpha1.PhaseReady,
+				Conditions: []metav1.Condition{{
+					Type:   v1alpha1.ConditionOSDDegraded,
+					Status: metav1.ConditionFalse,
+					Reason: "StorageClusterHealthy",
+				}},
+			},
+		}
+	}
+
+	When("a hub ManagedOCS is converted to v1beta1 and back", func() {
+		It("should be left unchanged", func() {
+			converted := &ManagedOCS{}
+			Expect(converted.ConvertFrom(newHub())).Should(Succeed())
+
+			roundTripped := &v1alpha1.ManagedOCS{}
+			Expect(converted.ConvertTo(roundTripped)).Should(Succeed())
+
+			Expect(roundTripped).To(Equal(newHub()))
+		})
+	})
+
+	When("the converted ManagedOCS is modified", func() {
+		It("should leave the source unchanged", func() {
+			hub := newHub()
+			converted := &ManagedOCS{}
+			Expect(converted.ConvertFrom(hub)).Should(Succeed())
+
+			*converted.Spec.StorageDeviceSetCount = 5
+			converted.Status.Conditions[0].Status = metav1.ConditionTrue
+
+			Expect(hub).To(Equal(newHub()))
+		})
+	})
+})
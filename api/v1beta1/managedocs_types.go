@@ -0,0 +1,57 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mocs
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="STRATEGY",type="string",JSONPath=".status.reconcileStrategy"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"ocs.openshift.io/Available\")].status"
+// +kubebuilder:printcolumn:name="CAPACITY",type="integer",JSONPath=".status.totalCapacityBytes"
+// +kubebuilder:printcolumn:name="LAST_SYNC",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ERROR",type="string",JSONPath=".status.errorMessage",priority=1
+
+// ManagedOCS is the Schema for the managedocs API. It shares the spec and status of
+// v1alpha1 until a breaking change is introduced
+type ManagedOCS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   v1alpha1.ManagedOCSSpec   `json:"spec,omitempty"`
+	Status v1alpha1.ManagedOCSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManagedOCSList contains a list of ManagedOCS
+type ManagedOCSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagedOCS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ManagedOCS{}, &ManagedOCSList{})
+}
@@ -0,0 +1,101 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const eventReasonStorageClusterAdopted = "StorageClusterAdopted"
+
+// AdoptExistingStorageCluster patches sc with a controller reference to managedOCS. Only the
+// owner references are patched, the spec of the adopted storage cluster is left untouched
+func AdoptExistingStorageCluster(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	managedOCS *v1.ManagedOCS, sc *ocsv1.StorageCluster) error {
+	patch := client.MergeFrom(sc.DeepCopy())
+	if err := ctrl.SetControllerReference(managedOCS, sc, scheme); err != nil {
+		return err
+	}
+	if err := c.Patch(ctx, sc, patch); err != nil {
+		return fmt.Errorf("unable to adopt StorageCluster %v: %w", sc.Name, err)
+	}
+	return nil
+}
+
+// adoptStorageCluster takes ownership of the loaded storage cluster and switches the ManagedOCS
+// resource to the none reconcile strategy, so the adopted spec is kept until the reconcile
+// strategy is explicitly changed. The spec update triggers the next reconcile
+func (r *ManagedOCSReconciler) adoptStorageCluster(ctx context.Context) error {
+	if err := AdoptExistingStorageCluster(ctx, r.Client, r.Scheme, r.managedOCS, r.storageCluster); err != nil {
+		return err
+	}
+	r.Log.Info("StorageCluster adopted", "name", r.storageCluster.Name)
+	r.recordEvent(corev1.EventTypeNormal, eventReasonStorageClusterAdopted,
+		"StorageCluster %v adopted, reconcile strategy set to %v", r.storageCluster.Name, v1.ReconcileStrategyNone)
+
+	r.managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
+	// The update response overwrites the status computed so far in this reconcile
+	status := r.managedOCS.Status.DeepCopy()
+	if err := r.update(ctx, r.managedOCS); err != nil {
+		return fmt.Errorf("unable to set the reconcile strategy of the adopted StorageCluster: %w", err)
+	}
+	r.managedOCS.Status = *status
+	r.reconcileStrategy = v1.ReconcileStrategyNone
+	return nil
+}
+
+// refuseStorageClusterAdoption records that the loaded storage cluster is left alone, as it was
+// not created by the deployer and spec.adoptExistingCluster is not set
+func (r *ManagedOCSReconciler) refuseStorageClusterAdoption() {
+	r.Log.Info("StorageCluster is not owned by a ManagedOCS resource, refusing to adopt it", "name", r.storageCluster.Name)
+	r.adoptionRefusedStorageClusters = append(r.adoptionRefusedStorageClusters, r.storageCluster.Name)
+}
+
+// updateAdoptionRefusedCondition sets the AdoptionRefused condition while some of the managed
+// storage clusters exist and are not owned by a ManagedOCS resource
+func (r *ManagedOCSReconciler) updateAdoptionRefusedCondition() {
+	if len(r.adoptionRefusedStorageClusters) == 0 {
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionAdoptionRefused)
+		return
+	}
+
+	message := "StorageClusters " + strings.Join(r.adoptionRefusedStorageClusters, ", ") +
+		" already exist and are not owned by a ManagedOCS resource, set spec.adoptExistingCluster to adopt them"
+	if !meta.IsStatusConditionTrue(r.managedOCS.Status.Conditions, v1.ConditionAdoptionRefused) {
+		r.recordEvent(corev1.EventTypeWarning, eventReasonStorageClusterAdoptionRefused, "%s", message)
+	}
+	meta.SetStatusCondition(&r.managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionAdoptionRefused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: r.managedOCS.Generation,
+		Reason:             "StorageClusterNotOwned",
+		Message:            message,
+	})
+}
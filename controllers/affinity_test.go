@@ -0,0 +1,54 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+var _ = Describe("Storage device set pod anti-affinity", func() {
+	When("no affinity is set", func() {
+		It("should spread the OSDs across zones", func() {
+			spec := newTestStorageClusterSpec(nil)
+			applyPodAntiAffinity(spec, nil)
+			for _, deviceSet := range spec.StorageDeviceSets {
+				terms := deviceSet.Placement.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+				Expect(terms).To(HaveLen(1))
+				Expect(terms[0].PodAffinityTerm.TopologyKey).To(Equal(utils.ZoneTopologyKey))
+				Expect(terms[0].PodAffinityTerm.LabelSelector.MatchExpressions[0].Values).To(ConsistOf(osdLabelValue))
+			}
+		})
+	})
+	When("an affinity is set", func() {
+		It("should apply its pod anti-affinity to all the storage device sets", func() {
+			antiAffinity := &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+					TopologyKey: "kubernetes.io/hostname",
+				}},
+			}
+			spec := newTestStorageClusterSpec(nil)
+			applyPodAntiAffinity(spec, &corev1.Affinity{PodAntiAffinity: antiAffinity})
+			for _, deviceSet := range spec.StorageDeviceSets {
+				Expect(deviceSet.Placement.PodAntiAffinity).To(Equal(antiAffinity))
+			}
+		})
+	})
+})
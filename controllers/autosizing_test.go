@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+)
+
+var _ = Describe("Storage device set auto sizing", func() {
+	newSpec := func(counts map[string]int) *ocsv1.StorageClusterSpec {
+		spec := &ocsv1.StorageClusterSpec{}
+		for _, name := range []string{"default", "extra"} {
+			if count, ok := counts[name]; ok {
+				spec.StorageDeviceSets = append(spec.StorageDeviceSets, ocsv1.StorageDeviceSet{Name: name, Count: count})
+			}
+		}
+		return spec
+	}
+
+	When("the storage cluster does not exist yet", func() {
+		It("should use the auto sized count", func() {
+			desired := newSpec(map[string]int{"default": 1, "extra": 1})
+			applyAutoSizedDeviceSetCount(desired, &ocsv1.StorageClusterSpec{}, 4)
+			Expect(desired).To(Equal(newSpec(map[string]int{"default": 4, "extra": 4})))
+		})
+	})
+
+	When("the auto sized count is lower than the current count", func() {
+		It("should keep the current count", func() {
+			desired := newSpec(map[string]int{"default": 1, "extra": 1})
+			current := newSpec(map[string]int{"default": 6, "extra": 2})
+			applyAutoSizedDeviceSetCount(desired, current, 4)
+			Expect(desired).To(Equal(newSpec(map[string]int{"default": 6, "extra": 4})))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	goerrors "errors"
+	"net"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	initialRetryBackoff = 5 * time.Second
+	maxRetryBackoff     = 5 * time.Minute
+	retryBackoffJitter  = 0.1
+
+	// apiRetryAttempts is the number of attempts of the API calls retried in place
+	apiRetryAttempts = 3
+)
+
+// isRetriableError reports whether err is a transient API server failure that is
+// expected to resolve by itself, such as a timeout or an etcd leader election.
+// Reconcile errors are retriable when flagged so by the failing phase
+func isRetriableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Reconcile errors know whether retrying can succeed
+	if reconcileErr, ok := deployererrors.AsReconcileError(err); ok {
+		return reconcileErr.Retryable
+	}
+
+	if errors.IsTimeout(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTooManyRequests(err) ||
+		errors.IsServiceUnavailable(err) ||
+		errors.IsUnexpectedServerError(err) {
+		return true
+	}
+
+	// etcd leader changes surface as internal errors from the API server
+	if errors.IsInternalError(err) && strings.Contains(err.Error(), "leader changed") {
+		return true
+	}
+
+	var netErr net.Error
+	if goerrors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
+
+// nextRetryBackoff returns the backoff to use after the given one, doubling it
+// and capping it at maxRetryBackoff. A zero backoff starts at initialRetryBackoff
+func nextRetryBackoff(current time.Duration) time.Duration {
+	if current <= 0 {
+		return initialRetryBackoff
+	}
+	next := current * 2
+	if next > maxRetryBackoff {
+		next = maxRetryBackoff
+	}
+	return next
+}
+
+// jitterRetryBackoff spreads requeues of the same backoff over a small random window
+func jitterRetryBackoff(backoff time.Duration) time.Duration {
+	return wait.Jitter(backoff, retryBackoffJitter)
+}
+
+// retryTransient calls fn until it succeeds, retrying it in place with a short backoff while
+// it fails with a retriable error. Other errors are returned right away
+func retryTransient(ctx context.Context, fn func() error) error {
+	var permanentErr error
+	err := utils.RetryWithBackoff(ctx, apiRetryAttempts, func() error {
+		err := fn()
+		if err != nil && !isRetriableError(err) {
+			permanentErr = err
+			return nil
+		}
+		return err
+	})
+	if permanentErr != nil {
+		return permanentErr
+	}
+	return err
+}
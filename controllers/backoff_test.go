@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+)
+
+var _ = Describe("Reconcile retry backoff", func() {
+	resource := schema.GroupResource{Group: "ocs.openshift.io", Resource: "managedocs"}
+
+	Context("isRetriableError", func() {
+		It("should classify transient API server errors as retriable", func() {
+			Expect(isRetriableError(errors.NewTimeoutError("timeout", 1))).Should(BeTrue())
+			Expect(isRetriableError(errors.NewServerTimeout(resource, "get", 1))).Should(BeTrue())
+			Expect(isRetriableError(errors.NewTooManyRequests("slow down", 1))).Should(BeTrue())
+			Expect(isRetriableError(errors.NewServiceUnavailable("unavailable"))).Should(BeTrue())
+			Expect(isRetriableError(errors.NewInternalError(goerrors.New("etcdserver: leader changed")))).Should(BeTrue())
+		})
+		It("should classify wrapped transient errors as retriable", func() {
+			err := fmt.Errorf("unable to delete storagecluster: %w", errors.NewTimeoutError("timeout", 1))
+			Expect(isRetriableError(err)).Should(BeTrue())
+		})
+		It("should classify other errors as non retriable", func() {
+			Expect(isRetriableError(nil)).Should(BeFalse())
+			Expect(isRetriableError(goerrors.New("invalid size"))).Should(BeFalse())
+			Expect(isRetriableError(errors.NewNotFound(resource, "managedocs"))).Should(BeFalse())
+			Expect(isRetriableError(errors.NewBadRequest("bad request"))).Should(BeFalse())
+			Expect(isRetriableError(errors.NewInternalError(goerrors.New("boom")))).Should(BeFalse())
+		})
+		It("should honour the retryable flag of reconcile errors", func() {
+			timeout := errors.NewTimeoutError("timeout", 1)
+			Expect(isRetriableError(deployererrors.NewTemplateError("template", "TemplateInvalid", false, timeout))).Should(BeFalse())
+			Expect(isRetriableError(deployererrors.NewReadinessError("ocs-operator", "InstallFailed", true, goerrors.New("failed")))).Should(BeTrue())
+		})
+	})
+
+	Context("nextRetryBackoff", func() {
+		It("should start at the initial backoff, double and cap at the max backoff", func() {
+			Expect(nextRetryBackoff(0)).Should(Equal(initialRetryBackoff))
+			Expect(nextRetryBackoff(initialRetryBackoff)).Should(Equal(2 * initialRetryBackoff))
+			Expect(nextRetryBackoff(4 * time.Minute)).Should(Equal(maxRetryBackoff))
+			Expect(nextRetryBackoff(maxRetryBackoff)).Should(Equal(maxRetryBackoff))
+		})
+		It("should jitter the backoff within a small window", func() {
+			backoff := jitterRetryBackoff(initialRetryBackoff)
+			Expect(backoff).Should(BeNumerically(">=", initialRetryBackoff))
+			Expect(backoff).Should(BeNumerically("<=", time.Duration(float64(initialRetryBackoff)*(1+retryBackoffJitter))))
+		})
+	})
+
+	Context("retryTransient", func() {
+		It("should retry retriable errors until the call succeeds", func() {
+			attempts := 0
+			Expect(retryTransient(context.Background(), func() error {
+				attempts++
+				if attempts < 2 {
+					return errors.NewServerTimeout(resource, "update", 1)
+				}
+				return nil
+			})).Should(Succeed())
+			Expect(attempts).Should(Equal(2))
+		})
+		It("should return other errors right away", func() {
+			attempts := 0
+			err := retryTransient(context.Background(), func() error {
+				attempts++
+				return errors.NewConflict(resource, "managedocs", goerrors.New("modified"))
+			})
+			Expect(errors.IsConflict(err)).Should(BeTrue())
+			Expect(attempts).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	// backpressureCapacityPercent is the utilization of the raw capacity above which new PVCs
+	// of the managed StorageClasses are rejected
+	backpressureCapacityPercent = 85
+
+	backpressureWebhookConfigPrefix = "managed-ocs-backpressure"
+	backpressureWebhookName         = "backpressure.ocs.openshift.io"
+
+	// The readiness server handles the admission of the PVCs, over TLS, behind its Service
+	backpressureServiceName = "ocs-osd-readiness-server"
+	backpressureServicePort = 8443
+	backpressurePath        = "/backpressure"
+
+	// injectCABundleAnnotation has the OpenShift service CA operator inject the CA bundle of
+	// the serving certificate of the readiness server into the webhook configuration
+	injectCABundleAnnotation = "service.beta.openshift.io/inject-cabundle"
+
+	backpressureCapacityReason = "CapacityExceeded"
+	backpressureDegradedReason = "StorageClusterDegraded"
+
+	eventReasonBackpressureApplied  = "BackpressureApplied"
+	eventReasonBackpressureReleased = "BackpressureReleased"
+)
+
+// +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,verbs=get;create;update;delete
+
+// reconcileBackpressure rejects new PVCs of the managed StorageClasses while the StorageCluster
+// cannot take more load. A ValidatingWebhookConfiguration sending the PVC creations to the
+// readiness server is created while the Backpressure condition is set, and deleted once it is
+// cleared. The readiness server only rejects the PVCs of the managed StorageClasses
+func (r *ManagedOCSReconciler) reconcileBackpressure(ctx context.Context) error {
+	wasApplied := meta.IsStatusConditionTrue(r.managedOCS.Status.Conditions, v1.ConditionBackpressure)
+	applied := updateBackpressureCondition(r.managedOCS)
+	if applied && !wasApplied {
+		condition := meta.FindStatusCondition(r.managedOCS.Status.Conditions, v1.ConditionBackpressure)
+		r.recordEvent(corev1.EventTypeWarning, eventReasonBackpressureApplied, "New PVCs are rejected: %s", condition.Message)
+	} else if !applied && wasApplied {
+		r.recordEvent(corev1.EventTypeNormal, eventReasonBackpressureReleased, "New PVCs are admitted again")
+	}
+
+	if !applied {
+		return r.deleteBackpressureWebhookConfig(ctx)
+	}
+
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	webhookConfig.Name = getBackpressureWebhookConfigName(r.namespace)
+	r.Log.Info("Reconciling backpressure ValidatingWebhookConfiguration", "name", webhookConfig.Name)
+	_, err := ctrl.CreateOrUpdate(ctx, r.UnrestrictedClient, webhookConfig, func() error {
+		metav1.SetMetaDataAnnotation(&webhookConfig.ObjectMeta, injectCABundleAnnotation, "true")
+		// The CA bundle is injected by the service CA operator, it is kept across updates
+		var caBundle []byte
+		if len(webhookConfig.Webhooks) > 0 {
+			caBundle = webhookConfig.Webhooks[0].ClientConfig.CABundle
+		}
+		webhookConfig.Webhooks = []admissionregistrationv1.ValidatingWebhook{
+			getDesiredBackpressureWebhook(r.namespace, caBundle),
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to update backpressure ValidatingWebhookConfiguration: %w", err)
+	}
+	return nil
+}
+
+// deleteBackpressureWebhookConfig deletes the backpressure ValidatingWebhookConfiguration of the
+// ManagedOCS resource. It is cluster scoped, so it is not garbage collected with the ManagedOCS
+// resource and is deleted on its deletion
+func (r *ManagedOCSReconciler) deleteBackpressureWebhookConfig(ctx context.Context) error {
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	webhookConfig.Name = getBackpressureWebhookConfigName(r.namespace)
+	if err := r.UnrestrictedClient.Delete(ctx, webhookConfig); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("Failed to delete backpressure ValidatingWebhookConfiguration: %w", err)
+	}
+	return nil
+}
+
+// updateBackpressureCondition sets the Backpressure condition while the utilization of the raw
+// capacity of the StorageCluster exceeds backpressureCapacityPercent, or while the
+// StorageCluster is degraded. It returns whether the condition is set
+func updateBackpressureCondition(managedOCS *v1.ManagedOCS) bool {
+	var reason, message string
+	status := &managedOCS.Status
+	if status.TotalCapacityBytes > 0 {
+		utilization := float64(status.UsedCapacityBytes) * 100 / float64(status.TotalCapacityBytes)
+		if utilization > backpressureCapacityPercent {
+			reason = backpressureCapacityReason
+			message = fmt.Sprintf("Storage utilization is %.1f%%, above %d%%", utilization, backpressureCapacityPercent)
+		}
+	}
+	if reason == "" && meta.IsStatusConditionTrue(status.Conditions, v1.ConditionStorageClusterDegraded) {
+		reason = backpressureDegradedReason
+		message = "The StorageCluster is degraded"
+	}
+	if reason == "" {
+		utils.RemoveStatusCondition(&status.Conditions, v1.ConditionBackpressure)
+		return false
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1.ConditionBackpressure,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
+
+// getBackpressureWebhookConfigName returns the name of the backpressure
+// ValidatingWebhookConfiguration of the ManagedOCS resource in the given namespace
+func getBackpressureWebhookConfigName(namespace string) string {
+	return fmt.Sprintf("%s-%s", backpressureWebhookConfigPrefix, namespace)
+}
+
+// getDesiredBackpressureWebhook returns the webhook sending the PVC creations to the readiness
+// server in the given namespace. The readiness server being unavailable does not block the PVC
+// creations, including the ones of other StorageClasses
+func getDesiredBackpressureWebhook(namespace string, caBundle []byte) admissionregistrationv1.ValidatingWebhook {
+	path := backpressurePath
+	port := int32(backpressureServicePort)
+	failurePolicy := admissionregistrationv1.Ignore
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	return admissionregistrationv1.ValidatingWebhook{
+		Name: backpressureWebhookName,
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: namespace,
+				Name:      backpressureServiceName,
+				Path:      &path,
+				Port:      &port,
+			},
+			CABundle: caBundle,
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"persistentvolumeclaims"},
+			},
+		}},
+		FailurePolicy:           &failurePolicy,
+		SideEffects:             &sideEffects,
+		AdmissionReviewVersions: []string{"v1beta1"},
+	}
+}
@@ -0,0 +1,73 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Storage backpressure", func() {
+	var managedOCS *v1.ManagedOCS
+
+	BeforeEach(func() {
+		managedOCS = &v1.ManagedOCS{}
+		managedOCS.Status.TotalCapacityBytes = 100
+	})
+
+	It("should apply backpressure once the utilization exceeds 85%", func() {
+		managedOCS.Status.UsedCapacityBytes = 85
+		Expect(updateBackpressureCondition(managedOCS)).To(BeFalse())
+		Expect(meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionBackpressure)).To(BeNil())
+
+		managedOCS.Status.UsedCapacityBytes = 90
+		Expect(updateBackpressureCondition(managedOCS)).To(BeTrue())
+		condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionBackpressure)
+		Expect(condition.Reason).To(Equal(backpressureCapacityReason))
+
+		managedOCS.Status.UsedCapacityBytes = 50
+		Expect(updateBackpressureCondition(managedOCS)).To(BeFalse())
+		Expect(meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionBackpressure)).To(BeNil())
+	})
+
+	It("should apply backpressure while the StorageCluster is degraded", func() {
+		meta.SetStatusCondition(&managedOCS.Status.Conditions, metav1.Condition{
+			Type:   v1.ConditionStorageClusterDegraded,
+			Status: metav1.ConditionTrue,
+			Reason: "Degraded",
+		})
+		Expect(updateBackpressureCondition(managedOCS)).To(BeTrue())
+		condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionBackpressure)
+		Expect(condition.Reason).To(Equal(backpressureDegradedReason))
+	})
+
+	It("should send the PVC creations to the readiness server", func() {
+		webhook := getDesiredBackpressureWebhook("openshift-storage", []byte("ca"))
+		Expect(webhook.ClientConfig.Service.Namespace).To(Equal("openshift-storage"))
+		Expect(*webhook.ClientConfig.Service.Path).To(Equal(backpressurePath))
+		Expect(webhook.ClientConfig.CABundle).To(Equal([]byte("ca")))
+		Expect(*webhook.FailurePolicy).To(Equal(admissionregistrationv1.Ignore))
+		Expect(webhook.Rules).To(HaveLen(1))
+		Expect(webhook.Rules[0].Operations).To(ConsistOf(admissionregistrationv1.Create))
+		Expect(webhook.Rules[0].Resources).To(ConsistOf("persistentvolumeclaims"))
+	})
+})
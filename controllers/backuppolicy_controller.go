@@ -0,0 +1,201 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils/schedule"
+)
+
+const (
+	// backupPolicyLabelKey labels the backup ConfigMaps with the name of their BackupPolicy
+	backupPolicyLabelKey = "ocs.openshift.io/backup-policy"
+
+	backupManagedOCSSpecKey     = "managedocs.json"
+	backupStorageClusterSpecKey = "storagecluster.json"
+	backupTimestampFormat       = "20060102-150405"
+
+	defaultBackupRetentionCount = 7
+)
+
+// BackupPolicyReconciler takes scheduled backups of the spec of a ManagedOCS resource and of its
+// StorageCluster into ConfigMaps in the same namespace. A backup is restored by creating a new
+// ManagedOCS resource with the restore-from-backup annotation set to the backup ConfigMap name.
+type BackupPolicyReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=backuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=backuppolicies/status,verbs=get;update;patch
+
+// SetupWithManager creates and sets up a BackupPolicyReconciler to work with the provided manager
+func (r *BackupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Status updates are ignored, the next backups are scheduled with requeues
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.BackupPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile takes a backup when the schedule of the BackupPolicy is due, prunes the backups
+// beyond the retention count and requeues until the next scheduled backup
+func (r *BackupPolicyReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	policy := &v1.BackupPolicy{}
+	if err := r.Client.Get(ctx, req.NamespacedName, policy); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(-1).Info("BackupPolicy resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	backupSchedule, err := schedule.Parse(policy.Spec.Schedule)
+	if err != nil {
+		// Retrying does not help, the policy is reconciled again once its spec is updated
+		log.Error(err, "Invalid BackupPolicy schedule")
+		return ctrl.Result{}, nil
+	}
+
+	lastBackupTime := policy.CreationTimestamp.Time
+	if policy.Status.LastBackupTime != nil {
+		lastBackupTime = policy.Status.LastBackupTime.Time
+	}
+	now := time.Now().UTC()
+	if next := backupSchedule.Next(lastBackupTime); next.IsZero() {
+		log.Info("BackupPolicy schedule never activates", "schedule", policy.Spec.Schedule)
+		return ctrl.Result{}, nil
+	} else if now.Before(next) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	backup, err := r.takeBackup(ctx, policy, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Backup taken", "backup", backup.Name)
+	if err := r.pruneBackups(ctx, policy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	policy.Status.LastBackupTime = &metav1.Time{Time: now}
+	policy.Status.LastBackupName = backup.Name
+	if err := r.Client.Status().Update(ctx, policy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	next := backupSchedule.Next(now)
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// takeBackup saves the spec of the ManagedOCS resource referenced by the policy, and the spec
+// of its StorageCluster if it exists, into a new ConfigMap owned by the policy
+func (r *BackupPolicyReconciler) takeBackup(ctx context.Context, policy *v1.BackupPolicy, now time.Time) (*corev1.ConfigMap, error) {
+	managedOCS := &v1.ManagedOCS{}
+	managedOCSKey := types.NamespacedName{Name: policy.Spec.ManagedOCSRef.Name, Namespace: policy.Namespace}
+	if err := r.Client.Get(ctx, managedOCSKey, managedOCS); err != nil {
+		return nil, fmt.Errorf("unable to get ManagedOCS %v: %w", managedOCSKey.Name, err)
+	}
+	managedOCSSpec, err := json.Marshal(&managedOCS.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal ManagedOCS spec: %w", err)
+	}
+
+	backup := &corev1.ConfigMap{}
+	backup.Name = fmt.Sprintf("%s-%s", policy.Name, now.Format(backupTimestampFormat))
+	backup.Namespace = policy.Namespace
+	backup.Labels = map[string]string{backupPolicyLabelKey: policy.Name}
+	backup.Data = map[string]string{backupManagedOCSSpecKey: string(managedOCSSpec)}
+
+	storageCluster := &ocsv1.StorageCluster{}
+	storageClusterKey := types.NamespacedName{Name: getStorageClusterName(managedOCS), Namespace: policy.Namespace}
+	if err := r.Client.Get(ctx, storageClusterKey, storageCluster); err == nil {
+		storageClusterSpec, err := json.Marshal(&storageCluster.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal StorageCluster spec: %w", err)
+		}
+		backup.Data[backupStorageClusterSpecKey] = string(storageClusterSpec)
+	} else if !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get StorageCluster %v: %w", storageClusterKey.Name, err)
+	}
+
+	if err := ctrl.SetControllerReference(policy, backup, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(ctx, backup); err != nil {
+		return nil, fmt.Errorf("unable to create backup ConfigMap %v: %w", backup.Name, err)
+	}
+	return backup, nil
+}
+
+// pruneBackups deletes the oldest backups of the policy beyond its retention count
+func (r *BackupPolicyReconciler) pruneBackups(ctx context.Context, policy *v1.BackupPolicy) error {
+	backupList := &corev1.ConfigMapList{}
+	if err := r.Client.List(ctx, backupList,
+		client.InNamespace(policy.Namespace),
+		client.MatchingLabels{backupPolicyLabelKey: policy.Name},
+	); err != nil {
+		return fmt.Errorf("unable to list backups: %w", err)
+	}
+
+	retentionCount := defaultBackupRetentionCount
+	if policy.Spec.RetentionCount != nil {
+		retentionCount = int(*policy.Spec.RetentionCount)
+	}
+	for _, backup := range getExpiredBackups(backupList.Items, retentionCount) {
+		if err := r.Client.Delete(ctx, &backup); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete backup ConfigMap %v: %w", backup.Name, err)
+		}
+	}
+	return nil
+}
+
+// getExpiredBackups returns the backups beyond the retention count, oldest first. Backup names
+// end with their timestamp, so they sort chronologically.
+func getExpiredBackups(backups []corev1.ConfigMap, retentionCount int) []corev1.ConfigMap {
+	if len(backups) <= retentionCount {
+		return nil
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name < backups[j].Name
+	})
+	return backups[:len(backups)-retentionCount]
+}
@@ -0,0 +1,61 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("BackupPolicy retention", func() {
+	newBackups := func(names ...string) []corev1.ConfigMap {
+		backups := make([]corev1.ConfigMap, len(names))
+		for i, name := range names {
+			backups[i].Name = name
+		}
+		return backups
+	}
+	backupNames := func(backups []corev1.ConfigMap) []string {
+		names := []string{}
+		for _, backup := range backups {
+			names = append(names, backup.Name)
+		}
+		return names
+	}
+
+	When("there are no more backups than the retention count", func() {
+		It("should not expire any backup", func() {
+			backups := newBackups("policy-20210310-020000", "policy-20210311-020000")
+			Expect(getExpiredBackups(backups, 2)).To(BeEmpty())
+		})
+	})
+	When("there are more backups than the retention count", func() {
+		It("should expire the oldest backups", func() {
+			backups := newBackups(
+				"policy-20210312-020000",
+				"policy-20210310-020000",
+				"policy-20210313-020000",
+				"policy-20210311-020000",
+			)
+			Expect(backupNames(getExpiredBackups(backups, 2))).To(Equal([]string{
+				"policy-20210310-020000",
+				"policy-20210311-020000",
+			}))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	// capacityCriticalPercent is the utilization above which the deployer is reported as not
+	// ready
+	capacityCriticalPercent = 95
+
+	// capacityAlertHysteresis is how far, in percent, the utilization must drop below the
+	// threshold to clear the CapacityWarning condition, so it does not flap
+	capacityAlertHysteresis = 5
+
+	// capacityRefreshInterval is the interval at which the capacity in use is read again, Ceph
+	// reports it in the CephCluster status, which is not watched
+	capacityRefreshInterval = time.Minute
+
+	capacityThresholdExceededReason = "CapacityThresholdExceeded"
+	eventReasonCapacityCritical     = "CapacityCritical"
+)
+
+// cephClusterGVK is the kind of the CephCluster created by the OCS operator for a StorageCluster.
+// The Rook Ceph API is not vendored, so the CephCluster is read as an unstructured object
+var cephClusterGVK = schema.GroupVersionKind{Group: "ceph.rook.io", Version: "v1", Kind: "CephCluster"}
+
+// +kubebuilder:rbac:groups=ceph.rook.io,namespace=system,resources=cephclusters,verbs=get
+
+// getUsedCapacityBytes returns the raw capacity in use reported by Ceph in the status of the
+// CephCluster of the StorageCluster. It returns 0 until Ceph reports it
+func getUsedCapacityBytes(ctx context.Context, c client.Client, storageCluster *ocsv1.StorageCluster) (int64, error) {
+	cephCluster := &unstructured.Unstructured{}
+	cephCluster.SetGroupVersionKind(cephClusterGVK)
+	// The OCS operator names the CephCluster after the StorageCluster
+	key := types.NamespacedName{Name: storageCluster.Name + "-cephcluster", Namespace: storageCluster.Namespace}
+	if err := c.Get(ctx, key, cephCluster); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("unable to get CephCluster %v: %w", key.Name, err)
+	}
+	used, _, err := unstructured.NestedInt64(cephCluster.Object, "status", "ceph", "capacity", "bytesUsed")
+	if err != nil {
+		return 0, fmt.Errorf("unable to read the capacity of CephCluster %v: %w", key.Name, err)
+	}
+	return used, nil
+}
+
+// getCapacityAlertThreshold returns the capacity alert threshold of the ManagedOCS, which
+// defaults to DefaultCapacityAlertThreshold
+func getCapacityAlertThreshold(managedOCS *v1.ManagedOCS) int32 {
+	if threshold := managedOCS.Spec.CapacityAlertThreshold; threshold != nil {
+		return *threshold
+	}
+	return v1.DefaultCapacityAlertThreshold
+}
+
+// updateCapacityWarningCondition sets the CapacityWarning condition while the utilization of
+// the raw capacity of the StorageCluster exceeds the capacity alert threshold. It returns
+// true when the utilization just exceeded capacityCriticalPercent
+func updateCapacityWarningCondition(managedOCS *v1.ManagedOCS) bool {
+	total := managedOCS.Status.TotalCapacityBytes
+	if total <= 0 {
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionCapacityWarning)
+		return false
+	}
+
+	utilization := float64(managedOCS.Status.UsedCapacityBytes) * 100 / float64(total)
+	threshold := getCapacityAlertThreshold(managedOCS)
+	current := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionCapacityWarning)
+	warning := current != nil && current.Status == metav1.ConditionTrue
+	wasCritical := warning && current.Reason == v1.CapacityCriticalReason
+	if utilization <= float64(threshold) && (!warning || utilization < float64(threshold-capacityAlertHysteresis)) {
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionCapacityWarning)
+		return false
+	}
+
+	reason := capacityThresholdExceededReason
+	if utilization > capacityCriticalPercent {
+		reason = v1.CapacityCriticalReason
+	}
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionCapacityWarning,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             reason,
+		Message:            fmt.Sprintf("Storage utilization is %.1f%%, the alert threshold is %d%%", utilization, threshold),
+	})
+	return reason == v1.CapacityCriticalReason && !wasCritical
+}
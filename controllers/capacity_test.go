@@ -0,0 +1,155 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("StorageCluster capacity", func() {
+	newDeviceSet := func(size string, count, replica int) ocsv1.StorageDeviceSet {
+		deviceSet := ocsv1.StorageDeviceSet{Count: count, Replica: replica}
+		if size != "" {
+			deviceSet.DataPVCTemplate.Spec.Resources.Requests = corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(size),
+			}
+		}
+		return deviceSet
+	}
+
+	When("the StorageCluster has no storage device sets", func() {
+		It("should report no capacity", func() {
+			Expect(getStorageClusterCapacityBytes(&ocsv1.StorageCluster{})).To(BeZero())
+		})
+	})
+
+	When("the StorageCluster has multiple storage device sets", func() {
+		It("should aggregate the size of every device of every replica", func() {
+			storageCluster := &ocsv1.StorageCluster{}
+			storageCluster.Spec.StorageDeviceSets = []ocsv1.StorageDeviceSet{
+				newDeviceSet("1Ti", 2, 3),
+				newDeviceSet("512Gi", 1, 1),
+			}
+			Expect(getStorageClusterCapacityBytes(storageCluster)).To(Equal(int64(6<<40 + 512<<30)))
+		})
+	})
+
+	When("a storage device set does not set a replica", func() {
+		It("should use the ocs-operator default replica", func() {
+			storageCluster := &ocsv1.StorageCluster{}
+			storageCluster.Spec.StorageDeviceSets = []ocsv1.StorageDeviceSet{newDeviceSet("1Ti", 1, 0)}
+			Expect(getStorageClusterCapacityBytes(storageCluster)).To(Equal(int64(3 << 40)))
+		})
+	})
+
+	When("a storage device set does not request storage", func() {
+		It("should be left out of the capacity", func() {
+			storageCluster := &ocsv1.StorageCluster{}
+			storageCluster.Spec.StorageDeviceSets = []ocsv1.StorageDeviceSet{
+				newDeviceSet("", 1, 3),
+				newDeviceSet("1Ti", 1, 1),
+			}
+			Expect(getStorageClusterCapacityBytes(storageCluster)).To(Equal(int64(1 << 40)))
+		})
+	})
+	When("the CephCluster kind is not installed", func() {
+		It("should report no used capacity", func() {
+			storageCluster := &ocsv1.StorageCluster{}
+			storageCluster.Name = "capacity-storagecluster"
+			storageCluster.Namespace = testPrimaryNamespace
+			Expect(getUsedCapacityBytes(context.Background(), k8sClient, storageCluster)).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("Capacity warning", func() {
+	var managedOCS *v1.ManagedOCS
+
+	setUtilization := func(percent int64) {
+		managedOCS.Status.TotalCapacityBytes = 1000
+		managedOCS.Status.UsedCapacityBytes = percent * 10
+	}
+	conditionReason := func() string {
+		condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionCapacityWarning)
+		if condition == nil {
+			return ""
+		}
+		return condition.Reason
+	}
+
+	BeforeEach(func() {
+		managedOCS = &v1.ManagedOCS{}
+	})
+
+	It("should not warn below the threshold", func() {
+		setUtilization(80)
+		Expect(updateCapacityWarningCondition(managedOCS)).To(BeFalse())
+		Expect(conditionReason()).To(BeEmpty())
+	})
+
+	It("should warn above the default threshold", func() {
+		setUtilization(81)
+		Expect(updateCapacityWarningCondition(managedOCS)).To(BeFalse())
+		Expect(conditionReason()).To(Equal(capacityThresholdExceededReason))
+	})
+
+	It("should honour the threshold of the spec", func() {
+		threshold := int32(50)
+		managedOCS.Spec.CapacityAlertThreshold = &threshold
+		setUtilization(60)
+		updateCapacityWarningCondition(managedOCS)
+		Expect(conditionReason()).To(Equal(capacityThresholdExceededReason))
+	})
+
+	It("should only clear the warning once the utilization drops past the hysteresis", func() {
+		setUtilization(85)
+		updateCapacityWarningCondition(managedOCS)
+		setUtilization(76)
+		updateCapacityWarningCondition(managedOCS)
+		Expect(conditionReason()).To(Equal(capacityThresholdExceededReason))
+		setUtilization(74)
+		updateCapacityWarningCondition(managedOCS)
+		Expect(conditionReason()).To(BeEmpty())
+	})
+
+	It("should report the critical utilization once", func() {
+		setUtilization(96)
+		Expect(updateCapacityWarningCondition(managedOCS)).To(BeTrue())
+		Expect(conditionReason()).To(Equal(v1.CapacityCriticalReason))
+		Expect(updateCapacityWarningCondition(managedOCS)).To(BeFalse())
+		setUtilization(90)
+		Expect(updateCapacityWarningCondition(managedOCS)).To(BeFalse())
+		Expect(conditionReason()).To(Equal(capacityThresholdExceededReason))
+	})
+
+	It("should clear the warning without capacity", func() {
+		setUtilization(90)
+		updateCapacityWarningCondition(managedOCS)
+		managedOCS.Status.TotalCapacityBytes = 0
+		Expect(updateCapacityWarningCondition(managedOCS)).To(BeFalse())
+		Expect(conditionReason()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,49 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// maxConditionHistory is the number of records kept in the condition history
+const maxConditionHistory = 20
+
+// recordConditionHistory appends to the condition history of managedOCS the conditions of
+// previous that were removed, or whose status or reason changed, since previous was taken
+func recordConditionHistory(managedOCS *v1.ManagedOCS, previous []metav1.Condition) {
+	history := managedOCS.Status.ConditionHistory
+	for _, condition := range previous {
+		current := meta.FindStatusCondition(managedOCS.Status.Conditions, condition.Type)
+		if current != nil && current.Status == condition.Status && current.Reason == condition.Reason {
+			continue
+		}
+		history = append(history, v1.ConditionRecord{
+			Timestamp: condition.LastTransitionTime,
+			Type:      condition.Type,
+			Status:    condition.Status,
+			Reason:    condition.Reason,
+		})
+	}
+	if len(history) > maxConditionHistory {
+		history = history[len(history)-maxConditionHistory:]
+	}
+	managedOCS.Status.ConditionHistory = history
+}
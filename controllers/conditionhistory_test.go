@@ -0,0 +1,71 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Condition history", func() {
+	newCondition := func(conditionType string, status metav1.ConditionStatus, reason string) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: status, Reason: reason}
+	}
+
+	When("a condition is unchanged", func() {
+		It("should not record it", func() {
+			managedOCS := &v1.ManagedOCS{}
+			previous := []metav1.Condition{newCondition("Test", metav1.ConditionTrue, "Ok")}
+			managedOCS.Status.Conditions = []metav1.Condition{newCondition("Test", metav1.ConditionTrue, "Ok")}
+			recordConditionHistory(managedOCS, previous)
+			Expect(managedOCS.Status.ConditionHistory).To(BeEmpty())
+		})
+	})
+	When("a condition is overwritten or removed", func() {
+		It("should record the previous condition", func() {
+			managedOCS := &v1.ManagedOCS{}
+			previous := []metav1.Condition{
+				newCondition("Changed", metav1.ConditionFalse, "Failed"),
+				newCondition("Removed", metav1.ConditionTrue, "Ok"),
+			}
+			managedOCS.Status.Conditions = []metav1.Condition{newCondition("Changed", metav1.ConditionTrue, "Ok")}
+			recordConditionHistory(managedOCS, previous)
+			Expect(managedOCS.Status.ConditionHistory).To(Equal([]v1.ConditionRecord{
+				{Type: "Changed", Status: metav1.ConditionFalse, Reason: "Failed"},
+				{Type: "Removed", Status: metav1.ConditionTrue, Reason: "Ok"},
+			}))
+		})
+	})
+	When("the history is full", func() {
+		It("should drop the oldest records", func() {
+			managedOCS := &v1.ManagedOCS{}
+			for i := 0; i < maxConditionHistory; i++ {
+				managedOCS.Status.ConditionHistory = append(managedOCS.Status.ConditionHistory,
+					v1.ConditionRecord{Type: fmt.Sprintf("Old%d", i)})
+			}
+			recordConditionHistory(managedOCS, []metav1.Condition{newCondition("New", metav1.ConditionTrue, "Ok")})
+			Expect(managedOCS.Status.ConditionHistory).To(HaveLen(maxConditionHistory))
+			Expect(managedOCS.Status.ConditionHistory[0].Type).To(Equal("Old1"))
+			Expect(managedOCS.Status.ConditionHistory[maxConditionHistory-1].Type).To(Equal("New"))
+		})
+	})
+})
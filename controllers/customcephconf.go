@@ -0,0 +1,96 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	// The custom Ceph configuration is rendered in a block of its own. It is reconciled after
+	// the tuning policies, so its block comes last and takes precedence over them
+	customCephConfBlockBegin = "# BEGIN ManagedOCS custom Ceph configuration"
+	customCephConfBlockEnd   = "# END ManagedOCS custom Ceph configuration"
+)
+
+// reconcileCustomCephConf applies the custom Ceph configuration of the ManagedOCS spec to the
+// Rook Ceph configuration override. It is only applied when spec.allowCustomCephConf is set,
+// the block is removed otherwise
+func (r *ManagedOCSReconciler) reconcileCustomCephConf(ctx context.Context) error {
+	if r.isDryRun() {
+		return nil
+	}
+	r.Log.Info("Reconciling custom Ceph configuration")
+
+	customCephConf := r.managedOCS.Spec.CustomCephConf
+	if len(customCephConf) > 0 && !r.managedOCS.Spec.AllowCustomCephConf {
+		r.Log.Info("spec.allowCustomCephConf is not set, ignoring the custom Ceph configuration")
+		customCephConf = nil
+	}
+	sections := getCustomCephConfSections(customCephConf, r.Log)
+
+	configOverride := &corev1.ConfigMap{}
+	configOverride.Name = rookConfigOverrideName
+	configOverride.Namespace = r.namespace
+	if len(sections) == 0 {
+		// Without any option to set, only an existing custom block has to be removed
+		if err := r.get(ctx, configOverride); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, configOverride, func() error {
+		if configOverride.Data == nil {
+			configOverride.Data = map[string]string{}
+		}
+		configOverride.Data[rookConfigOverrideKey] = renderCephConfigBlock(configOverride.Data[rookConfigOverrideKey],
+			customCephConfBlockBegin, customCephConfBlockEnd, sections)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to apply custom Ceph configuration: %w", err)
+	}
+	return nil
+}
+
+// getCustomCephConfSections groups the custom Ceph configuration options by section. The
+// options are validated by the validating webhook, invalid ones are skipped here
+func getCustomCephConfSections(customCephConf map[string]string, log logr.Logger) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	for key, value := range customCephConf {
+		section, option, ok := utils.ParseCephConfKey(key)
+		if !ok || !utils.IsValidCephConfValue(value) {
+			log.Info("skipping invalid custom Ceph configuration option", "key", key)
+			continue
+		}
+		if sections[section] == nil {
+			sections[section] = map[string]string{}
+		}
+		sections[section][option] = value
+	}
+	return sections
+}
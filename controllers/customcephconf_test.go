@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Custom Ceph configuration", func() {
+	log := ctrl.Log.WithName("test")
+
+	It("should group the options by section, skipping the invalid ones", func() {
+		sections := getCustomCephConfSections(map[string]string{
+			"osd/osd_scrub_max_interval": "604800",
+			"global/debug_ms":            "0/0",
+			"osd_memory_target":          "1",
+			"mon/mon_allow_pool_delete":  "true\nauth_cluster_required = none",
+		}, log)
+		Expect(sections).To(Equal(map[string]map[string]string{
+			"osd":    {"osd_scrub_max_interval": "604800"},
+			"global": {"debug_ms": "0/0"},
+		}))
+	})
+
+	It("should render the sections in a block of their own", func() {
+		ocsConfig := "[global]\nmon_osd_full_ratio = .85\n"
+		sections := map[string]map[string]string{
+			"osd":    {"osd_scrub_max_interval": "604800"},
+			"global": {"debug_ms": "0/0"},
+		}
+		config := renderCephConfigBlock(ocsConfig, customCephConfBlockBegin, customCephConfBlockEnd, sections)
+		Expect(config).To(Equal(ocsConfig + customCephConfBlockBegin +
+			"\n[global]\ndebug_ms = 0/0\n[osd]\nosd_scrub_max_interval = 604800\n" + customCephConfBlockEnd + "\n"))
+		Expect(renderCephConfigBlock(config, customCephConfBlockBegin, customCephConfBlockEnd, nil)).To(Equal(ocsConfig))
+	})
+})
@@ -0,0 +1,54 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// debugLogger logs the verbose messages of the wrapped logger at its base level, so that the
+// verbose messages of a ManagedOCS resource in debug mode are logged without lowering the log
+// level shared by the loggers of the deployer
+type debugLogger struct {
+	logr.Logger
+}
+
+func (l debugLogger) V(level int) logr.Logger {
+	if level > 0 {
+		return l
+	}
+	return debugLogger{l.Logger.V(level)}
+}
+
+func (l debugLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return debugLogger{l.Logger.WithValues(keysAndValues...)}
+}
+
+func (l debugLogger) WithName(name string) logr.Logger {
+	return debugLogger{l.Logger.WithName(name)}
+}
+
+// applyDebugMode replaces the logger of the reconciler with a debug logger while the
+// ManagedOCS resource is in debug mode. It returns the function restoring the logger
+func (r *ManagedOCSReconciler) applyDebugMode() func() {
+	if !r.managedOCS.Spec.DebugMode {
+		return func() {}
+	}
+	log := r.Log
+	r.Log = debugLogger{log.WithValues("debugMode", true)}
+	return func() { r.Log = log }
+}
@@ -0,0 +1,61 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Debug mode", func() {
+	var out *bytes.Buffer
+	var reconciler *ManagedOCSReconciler
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		logLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+		reconciler = &ManagedOCSReconciler{
+			Log:        ctrlzap.New(ctrlzap.WriteTo(out), ctrlzap.Level(&logLevel)),
+			managedOCS: &v1.ManagedOCS{},
+		}
+	})
+
+	It("should not log the verbose messages outside of debug mode", func() {
+		restore := reconciler.applyDebugMode()
+		reconciler.Log.V(1).Info("verbose message")
+		restore()
+		Expect(out.String()).ToNot(ContainSubstring("verbose message"))
+	})
+
+	It("should log the verbose messages in debug mode until the logger is restored", func() {
+		reconciler.managedOCS.Spec.DebugMode = true
+		restore := reconciler.applyDebugMode()
+		Expect(reconciler.Log.V(1).Enabled()).To(BeTrue())
+		reconciler.Log.WithName("phase").V(1).Info("verbose message")
+		restore()
+		reconciler.Log.V(1).Info("restored message")
+		Expect(out.String()).To(ContainSubstring("verbose message"))
+		Expect(out.String()).ToNot(ContainSubstring("restored message"))
+	})
+})
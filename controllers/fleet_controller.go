@@ -0,0 +1,181 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+const (
+	// FleetStatusConfigMapName is the name of the ConfigMap, in the operator namespace, holding
+	// the status of all the ManagedOCS resources of the cluster
+	FleetStatusConfigMapName = "managed-ocs-fleet-status"
+
+	// fleetSummaryKey holds the count of ManagedOCS resources per phase. The other keys of the
+	// fleet status ConfigMap are <namespace>.<name> of each ManagedOCS resource
+	fleetSummaryKey = "summary"
+)
+
+// fleetMemberStatus is the status of a ManagedOCS resource in the fleet status ConfigMap
+type fleetMemberStatus struct {
+	Namespace    string             `json:"namespace"`
+	Name         string             `json:"name"`
+	Phase        v1.ManagedOCSPhase `json:"phase"`
+	LastSyncTime *metav1.Time       `json:"lastSyncTime,omitempty"`
+}
+
+// FleetStatusReconciler watches the ManagedOCS resources of all the namespaces and summarizes
+// their phase and last sync time in a ConfigMap of the operator namespace
+type FleetStatusReconciler struct {
+	Client    client.Client
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Namespace string
+
+	// fleetReader reads ManagedOCS resources from a cluster wide cache, the cache of the
+	// manager is restricted to the operator namespace
+	fleetReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=ocs.openshift.io,resources=managedocs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=system,resources=configmaps,verbs=get;list;watch;create;update
+
+// SetupWithManager creates and sets up a FleetStatusReconciler to work with the provided manager
+func (r *FleetStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	fleetCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create the fleet cache: %w", err)
+	}
+	if err := mgr.Add(fleetCache); err != nil {
+		return fmt.Errorf("unable to add the fleet cache to the manager: %w", err)
+	}
+	r.fleetReader = fleetCache
+
+	// Every ManagedOCS resource maps to the fleet status ConfigMap
+	enqueueFleetStatusRequest := handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(
+			func(obj handler.MapObject) []reconcile.Request {
+				return []reconcile.Request{{
+					NamespacedName: types.NamespacedName{
+						Name:      FleetStatusConfigMapName,
+						Namespace: r.Namespace,
+					},
+				}}
+			},
+		),
+	}
+	fleetStatusPredicate := builder.WithPredicates(
+		predicate.NewPredicateFuncs(
+			func(meta metav1.Object, _ runtime.Object) bool {
+				return meta.GetName() == FleetStatusConfigMapName
+			},
+		),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("fleet").
+		For(&corev1.ConfigMap{}, fleetStatusPredicate).
+		Watches(source.NewKindWithCache(&v1.ManagedOCS{}, fleetCache), &enqueueFleetStatusRequest).
+		Complete(r)
+}
+
+// Reconcile writes the status of all the ManagedOCS resources into the fleet status ConfigMap
+func (r *FleetStatusReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	managedOCSList := &v1.ManagedOCSList{}
+	if err := r.fleetReader.List(ctx, managedOCSList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list ManagedOCS resources: %w", err)
+	}
+	data, err := getFleetStatusData(managedOCSList)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMap.Name = FleetStatusConfigMapName
+	configMap.Namespace = r.Namespace
+	result, err := ctrl.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = data
+		return nil
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to reconcile the fleet status ConfigMap: %w", err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Fleet status ConfigMap reconciled", "result", result, "members", len(managedOCSList.Items))
+	}
+	return ctrl.Result{}, nil
+}
+
+// getFleetStatusData returns the data of the fleet status ConfigMap, with the status of each
+// ManagedOCS resource as JSON and the count of ManagedOCS resources per phase
+func getFleetStatusData(managedOCSList *v1.ManagedOCSList) (map[string]string, error) {
+	data := map[string]string{}
+	summary := map[v1.ManagedOCSPhase]int{}
+	for i := range managedOCSList.Items {
+		managedOCS := &managedOCSList.Items[i]
+		member := fleetMemberStatus{
+			Namespace: managedOCS.Namespace,
+			Name:      managedOCS.Name,
+			Phase:     managedOCS.Status.Phase,
+		}
+		if !managedOCS.Status.LastSyncTime.IsZero() {
+			member.LastSyncTime = managedOCS.Status.LastSyncTime.DeepCopy()
+		}
+		if member.Phase == "" {
+			member.Phase = v1.PhaseUnknown
+		}
+		memberJSON, err := json.Marshal(&member)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal the status of ManagedOCS %v/%v: %w", member.Namespace, member.Name, err)
+		}
+		// Namespaces cannot contain dots, the first dot separates the namespace from the name
+		data[managedOCS.Namespace+"."+managedOCS.Name] = string(memberJSON)
+		summary[member.Phase]++
+	}
+
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the fleet summary: %w", err)
+	}
+	data[fleetSummaryKey] = string(summaryJSON)
+	return data, nil
+}
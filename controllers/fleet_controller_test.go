@@ -0,0 +1,66 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Fleet status", func() {
+	newManagedOCS := func(namespace string, phase v1.ManagedOCSPhase) v1.ManagedOCS {
+		managedOCS := v1.ManagedOCS{}
+		managedOCS.Name = managedOCSName
+		managedOCS.Namespace = namespace
+		managedOCS.Status.Phase = phase
+		return managedOCS
+	}
+
+	It("should list the status of each ManagedOCS resource", func() {
+		lastSync := metav1.NewTime(time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC))
+		ready := newManagedOCS("tenant-a", v1.PhaseReady)
+		ready.Status.LastSyncTime = lastSync
+		data, err := getFleetStatusData(&v1.ManagedOCSList{Items: []v1.ManagedOCS{ready}})
+		Expect(err).ToNot(HaveOccurred())
+
+		member := fleetMemberStatus{}
+		Expect(json.Unmarshal([]byte(data["tenant-a."+managedOCSName]), &member)).To(Succeed())
+		Expect(member.Namespace).To(Equal("tenant-a"))
+		Expect(member.Phase).To(Equal(v1.PhaseReady))
+		Expect(member.LastSyncTime.Equal(&lastSync)).To(BeTrue())
+	})
+
+	It("should count the ManagedOCS resources per phase", func() {
+		data, err := getFleetStatusData(&v1.ManagedOCSList{Items: []v1.ManagedOCS{
+			newManagedOCS("tenant-a", v1.PhaseReady),
+			newManagedOCS("tenant-b", v1.PhaseReady),
+			newManagedOCS("tenant-c", ""),
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(HaveLen(4))
+
+		summary := map[v1.ManagedOCSPhase]int{}
+		Expect(json.Unmarshal([]byte(data[fleetSummaryKey]), &summary)).To(Succeed())
+		Expect(summary).To(Equal(map[v1.ManagedOCSPhase]int{v1.PhaseReady: 2, v1.PhaseUnknown: 1}))
+	})
+})
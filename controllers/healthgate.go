@@ -0,0 +1,84 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	// conditionStorageClusterRecovering is the StorageCluster condition reporting that Ceph is
+	// recovering, e.g. backfilling or scrubbing
+	conditionStorageClusterRecovering conditionsv1.ConditionType = "Recovering"
+
+	defaultRecoveryCheckInterval = 60 * time.Second
+
+	eventReasonStorageClusterUpdateDeferred = "StorageClusterUpdateDeferred"
+)
+
+// isStorageClusterRecovering checks whether Ceph is recovering, in which case applying a spec
+// change to the StorageCluster can slow the recovery down or make it fail
+func isStorageClusterRecovering(sc *ocsv1.StorageCluster) bool {
+	return conditionsv1.IsStatusConditionTrue(sc.Status.Conditions, conditionStorageClusterRecovering)
+}
+
+// getRecoveryCheckInterval returns the interval at which a StorageCluster whose update is
+// deferred is checked again
+func getRecoveryCheckInterval(managedOCS *v1.ManagedOCS) time.Duration {
+	if interval := managedOCS.Spec.RecoveryCheckInterval; interval != nil {
+		return time.Duration(*interval) * time.Second
+	}
+	return defaultRecoveryCheckInterval
+}
+
+// deferStorageClusterUpdate records that the update of sc is deferred while Ceph is recovering
+func (r *ManagedOCSReconciler) deferStorageClusterUpdate(sc *ocsv1.StorageCluster) {
+	interval := getRecoveryCheckInterval(r.managedOCS)
+	r.Log.Info("Ceph is recovering, deferring StorageCluster update", "name", sc.Name, "retryAfter", interval)
+	r.deferredStorageClusters = append(r.deferredStorageClusters, sc.Name)
+}
+
+// updateUpdateDeferredCondition sets the UpdateDeferred condition while the update of some of the
+// managed storage clusters is deferred
+func (r *ManagedOCSReconciler) updateUpdateDeferredCondition() {
+	if len(r.deferredStorageClusters) == 0 {
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionUpdateDeferred)
+		return
+	}
+
+	message := "Ceph is recovering, the update of StorageClusters " + strings.Join(r.deferredStorageClusters, ", ") + " is deferred"
+	if !meta.IsStatusConditionTrue(r.managedOCS.Status.Conditions, v1.ConditionUpdateDeferred) {
+		r.recordEvent(corev1.EventTypeWarning, eventReasonStorageClusterUpdateDeferred, "%s", message)
+	}
+	meta.SetStatusCondition(&r.managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionUpdateDeferred,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: r.managedOCS.Generation,
+		Reason:             "StorageClusterRecovering",
+		Message:            message,
+	})
+}
@@ -0,0 +1,67 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("StorageCluster health gate", func() {
+	newRecoveringStorageCluster := func() *ocsv1.StorageCluster {
+		sc := &ocsv1.StorageCluster{}
+		sc.Name = "ocs-storagecluster"
+		conditionsv1.SetStatusCondition(&sc.Status.Conditions, conditionsv1.Condition{
+			Type:   conditionStorageClusterRecovering,
+			Status: corev1.ConditionTrue,
+			Reason: "Backfilling",
+		})
+		return sc
+	}
+
+	It("should detect a recovering StorageCluster", func() {
+		Expect(isStorageClusterRecovering(&ocsv1.StorageCluster{})).To(BeFalse())
+		Expect(isStorageClusterRecovering(newRecoveringStorageCluster())).To(BeTrue())
+	})
+
+	It("should default the recovery check interval", func() {
+		managedOCS := &v1.ManagedOCS{}
+		Expect(getRecoveryCheckInterval(managedOCS)).To(Equal(defaultRecoveryCheckInterval))
+		interval := int32(30)
+		managedOCS.Spec.RecoveryCheckInterval = &interval
+		Expect(getRecoveryCheckInterval(managedOCS)).To(Equal(30 * time.Second))
+	})
+
+	It("should report the deferred StorageCluster updates in the UpdateDeferred condition", func() {
+		reconciler := &ManagedOCSReconciler{managedOCS: &v1.ManagedOCS{}}
+		reconciler.deferredStorageClusters = []string{"ocs-storagecluster"}
+		reconciler.updateUpdateDeferredCondition()
+		Expect(meta.IsStatusConditionTrue(reconciler.managedOCS.Status.Conditions, v1.ConditionUpdateDeferred)).To(BeTrue())
+
+		reconciler.deferredStorageClusters = nil
+		reconciler.updateUpdateDeferredCondition()
+		Expect(meta.FindStatusCondition(reconciler.managedOCS.Status.Conditions, v1.ConditionUpdateDeferred)).To(BeNil())
+	})
+})
@@ -0,0 +1,72 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// imageOverrideEnvVars maps the components of spec.imageOverrides to the environment variables
+// the ocs-operator reads the images of the components it deploys from
+var imageOverrideEnvVars = map[string]string{
+	v1.ImageOverrideCeph:     "CEPH_IMAGE",
+	v1.ImageOverrideRook:     "ROOK_CEPH_IMAGE",
+	v1.ImageOverrideNooBaa:   "NOOBAA_CORE_IMAGE",
+	v1.ImageOverrideNooBaaDB: "NOOBAA_DB_IMAGE",
+}
+
+// applyOCSOperatorImageOverrides sets the image environment variables of the ocs-operator
+// container to the overridden images. It returns whether the container was changed
+func applyOCSOperatorImageOverrides(container *corev1.Container, overrides map[string]string) bool {
+	var isChanged bool
+	for component, image := range overrides {
+		if envVar, found := imageOverrideEnvVars[component]; found && setContainerEnvVar(container, envVar, image) {
+			isChanged = true
+		}
+	}
+	return isChanged
+}
+
+// applyRookOperatorImageOverride sets the image of the rook-ceph-operator container to the
+// overridden rook image. It returns whether the container was changed
+func applyRookOperatorImageOverride(container *corev1.Container, overrides map[string]string) bool {
+	image := overrides[v1.ImageOverrideRook]
+	if image == "" || container.Image == image {
+		return false
+	}
+	container.Image = image
+	return true
+}
+
+// setContainerEnvVar sets the environment variable of the container to value, replacing a value
+// read from another source. It returns whether the container was changed
+func setContainerEnvVar(container *corev1.Container, name, value string) bool {
+	for i := range container.Env {
+		if envVar := &container.Env[i]; envVar.Name == name {
+			if envVar.Value == value && envVar.ValueFrom == nil {
+				return false
+			}
+			envVar.Value = value
+			envVar.ValueFrom = nil
+			return true
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+	return true
+}
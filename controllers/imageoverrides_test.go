@@ -0,0 +1,68 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Image overrides", func() {
+	var container *corev1.Container
+
+	BeforeEach(func() {
+		container = &corev1.Container{
+			Name:  "ocs-operator",
+			Image: "quay.io/ocs-dev/ocs-operator:4.6",
+			Env: []corev1.EnvVar{
+				{Name: "CEPH_IMAGE", Value: "quay.io/ceph/ceph:v15"},
+				{Name: "NOOBAA_CORE_IMAGE", ValueFrom: &corev1.EnvVarSource{}},
+			},
+		}
+	})
+
+	It("should override the image environment variables of the ocs-operator", func() {
+		changed := applyOCSOperatorImageOverrides(container, map[string]string{
+			v1.ImageOverrideCeph:     "registry.local/ceph/ceph:v15",
+			v1.ImageOverrideNooBaa:   "registry.local/noobaa/core:5.6",
+			v1.ImageOverrideNooBaaDB: "registry.local/noobaa/db:12",
+		})
+		Expect(changed).To(BeTrue())
+		Expect(container.Env).To(ConsistOf(
+			corev1.EnvVar{Name: "CEPH_IMAGE", Value: "registry.local/ceph/ceph:v15"},
+			corev1.EnvVar{Name: "NOOBAA_CORE_IMAGE", Value: "registry.local/noobaa/core:5.6"},
+			corev1.EnvVar{Name: "NOOBAA_DB_IMAGE", Value: "registry.local/noobaa/db:12"},
+		))
+	})
+
+	It("should not change the ocs-operator once the overrides are applied", func() {
+		overrides := map[string]string{v1.ImageOverrideCeph: "quay.io/ceph/ceph:v15"}
+		Expect(applyOCSOperatorImageOverrides(container, overrides)).To(BeFalse())
+		Expect(applyOCSOperatorImageOverrides(container, nil)).To(BeFalse())
+	})
+
+	It("should override the image of the rook-ceph-operator", func() {
+		container.Name = "rook-ceph-operator"
+		overrides := map[string]string{v1.ImageOverrideRook: "registry.local/rook/ceph:v1.5"}
+		Expect(applyRookOperatorImageOverride(container, overrides)).To(BeTrue())
+		Expect(container.Image).To(Equal("registry.local/rook/ceph:v1.5"))
+		Expect(applyRookOperatorImageOverride(container, overrides)).To(BeFalse())
+	})
+})
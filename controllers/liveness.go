@@ -0,0 +1,72 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultLivenessTimeout is the time a reconcile can run before the ManagedOCS controller is
+// reported as not alive, when the reconciler does not set one
+const DefaultLivenessTimeout = 5 * time.Minute
+
+// reconcileLiveness tracks the reconcile attempts of a controller, so that a reconcile stuck
+// in its goroutine can be detected from the health probe goroutine
+type reconcileLiveness struct {
+	mu                   sync.Mutex
+	lastReconcileAttempt time.Time
+	inProgress           bool
+}
+
+func (l *reconcileLiveness) start(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastReconcileAttempt = now
+	l.inProgress = true
+}
+
+func (l *reconcileLiveness) done() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inProgress = false
+}
+
+// check fails when the last reconcile attempt started more than timeout before now and did not
+// return yet. An idle controller is alive, reconciles are only expected on watch events and
+// requeues, which can be an hour apart
+func (l *reconcileLiveness) check(now time.Time, timeout time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.lastReconcileAttempt); l.inProgress && elapsed > timeout {
+		return fmt.Errorf("reconcile started at %v is still running after %v",
+			l.lastReconcileAttempt.UTC().Format(time.RFC3339), elapsed.Round(time.Second))
+	}
+	return nil
+}
+
+// LivenessCheck is a health check failing while a reconcile of the ManagedOCS controller runs
+// for longer than the liveness timeout
+func (r *ManagedOCSReconciler) LivenessCheck(_ *http.Request) error {
+	timeout := r.LivenessTimeout
+	if timeout <= 0 {
+		timeout = DefaultLivenessTimeout
+	}
+	return r.liveness.check(time.Now(), timeout)
+}
@@ -0,0 +1,53 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reconcile liveness", func() {
+	start := time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC)
+	var liveness *reconcileLiveness
+
+	BeforeEach(func() {
+		liveness = &reconcileLiveness{}
+	})
+
+	It("should be alive before the first reconcile", func() {
+		Expect(liveness.check(start, time.Minute)).To(Succeed())
+	})
+
+	It("should be alive while a reconcile runs within the timeout", func() {
+		liveness.start(start)
+		Expect(liveness.check(start.Add(time.Minute), 5*time.Minute)).To(Succeed())
+	})
+
+	It("should not be alive once a reconcile runs past the timeout", func() {
+		liveness.start(start)
+		Expect(liveness.check(start.Add(6*time.Minute), 5*time.Minute)).ToNot(Succeed())
+	})
+
+	It("should be alive while idle between reconciles", func() {
+		liveness.start(start)
+		liveness.done()
+		Expect(liveness.check(start.Add(time.Hour), 5*time.Minute)).To(Succeed())
+	})
+})
@@ -0,0 +1,39 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ = Describe("Log level", func() {
+	It("should report whether the level changed", func() {
+		logLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+		Expect(SetLogLevel(&logLevel, "info")).To(BeFalse())
+		Expect(SetLogLevel(&logLevel, "debug")).To(BeTrue())
+		Expect(logLevel.Level()).To(Equal(zapcore.DebugLevel))
+	})
+	It("should reject unknown levels", func() {
+		logLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+		_, err := SetLogLevel(&logLevel, "verbose")
+		Expect(err).To(HaveOccurred())
+		Expect(logLevel.Level()).To(Equal(zapcore.InfoLevel))
+	})
+})
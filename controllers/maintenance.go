@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+// checkMaintenanceWindow reports whether the maintenance window of the ManagedOCS is open at
+// now in the MaintenanceActive condition. It returns the time left until the window closes,
+// or zero when the window is not open
+func checkMaintenanceWindow(managedOCS *v1.ManagedOCS, now time.Time) time.Duration {
+	window := managedOCS.Spec.MaintenanceWindow
+	if window == nil || now.Before(window.Start.Time) || !now.Before(window.End.Time) {
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionMaintenanceActive)
+		return 0
+	}
+
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionMaintenanceActive,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             "MaintenanceWindowOpen",
+		Message:            "Reconciliation is suspended until " + window.End.UTC().Format(time.RFC3339),
+	})
+	return window.End.Sub(now)
+}
+
+// isMaintenanceActive checks whether the last reconcile was skipped for a maintenance window
+func isMaintenanceActive(managedOCS *v1.ManagedOCS) bool {
+	return meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionMaintenanceActive)
+}
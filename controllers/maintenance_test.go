@@ -0,0 +1,65 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Maintenance window", func() {
+	start := time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC)
+	var managedOCS *v1.ManagedOCS
+
+	BeforeEach(func() {
+		managedOCS = &v1.ManagedOCS{}
+		managedOCS.Spec.MaintenanceWindow = &v1.MaintenanceWindow{
+			Start: metav1.NewTime(start),
+			End:   metav1.NewTime(start.Add(2 * time.Hour)),
+		}
+	})
+
+	It("should be active until the window closes", func() {
+		Expect(checkMaintenanceWindow(managedOCS, start.Add(30*time.Minute))).Should(Equal(90 * time.Minute))
+		Expect(isMaintenanceActive(managedOCS)).Should(BeTrue())
+	})
+
+	It("should not be active outside of the window", func() {
+		Expect(checkMaintenanceWindow(managedOCS, start.Add(-time.Minute))).Should(BeZero())
+		Expect(isMaintenanceActive(managedOCS)).Should(BeFalse())
+		Expect(checkMaintenanceWindow(managedOCS, start.Add(2*time.Hour))).Should(BeZero())
+		Expect(isMaintenanceActive(managedOCS)).Should(BeFalse())
+	})
+
+	It("should clear the condition once the window closes", func() {
+		checkMaintenanceWindow(managedOCS, start.Add(time.Hour))
+		Expect(isMaintenanceActive(managedOCS)).Should(BeTrue())
+		checkMaintenanceWindow(managedOCS, start.Add(3*time.Hour))
+		Expect(isMaintenanceActive(managedOCS)).Should(BeFalse())
+	})
+
+	It("should not be active without a window", func() {
+		managedOCS.Spec.MaintenanceWindow = nil
+		Expect(checkMaintenanceWindow(managedOCS, start)).Should(BeZero())
+		Expect(isMaintenanceActive(managedOCS)).Should(BeFalse())
+	})
+})
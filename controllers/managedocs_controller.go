@@ -18,8 +18,10 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -30,13 +32,20 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -45,23 +54,46 @@ import (
 	"github.com/go-logr/logr"
 	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
 	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
 	"github.com/openshift/ocs-osd-deployer/templates"
 	"github.com/openshift/ocs-osd-deployer/utils"
+	"github.com/openshift/ocs-osd-deployer/utils/sizing"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	promv1a1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"go.uber.org/zap"
 )
 
 const (
 	ManagedOCSFinalizer = "managedocs.ocs.openshift.io"
+
+	// ReconcilePauseAnnotation stops all changes to the managed resources while set to "true"
+	ReconcilePauseAnnotation = "ocs.openshift.io/reconcile-pause"
+
+	// DryRunAnnotation stops all writes to the StorageCluster while set to "true", the changes
+	// the deployer would make are recorded in the LastDryRunDiffAnnotation instead
+	DryRunAnnotation = "ocs.openshift.io/dry-run"
+
+	// LastDryRunDiffAnnotation holds the strategic merge patch, as a JSON string, between the
+	// current and the desired StorageCluster spec computed by the last dry run
+	LastDryRunDiffAnnotation = "ocs.openshift.io/last-dry-run-diff"
+
+	// StorageClusterTemplateLabel marks, when set to "true", the storage cluster template
+	// ConfigMaps whose changes are watched
+	StorageClusterTemplateLabel = "ocs.openshift.io/template"
+
+	// AlertingRulesLabel marks, when set to "true", the custom alerting rules ConfigMaps whose
+	// changes are watched
+	AlertingRulesLabel = "ocs.openshift.io/alerting-rules"
 )
 
 const (
 	managedOCSName                         = "managedocs"
-	storageClusterName                     = "ocs-storagecluster"
 	prometheusName                         = "managed-ocs-prometheus"
 	alertmanagerName                       = "managed-ocs-alertmanager"
 	alertmanagerConfigName                 = "managed-ocs-alertmanager-config"
 	dmsRuleName                            = "dms-monitor-rule"
+	storageAlertsRuleName                  = "managed-ocs-storage-alerts"
+	alertingRulesKey                       = "prometheusrule.yaml"
 	storageClassSizeKey                    = "size"
 	deviceSetName                          = "default"
 	storageClassRbdName                    = "ocs-storagecluster-ceph-rbd"
@@ -70,12 +102,30 @@ const (
 	ocsOperatorName                        = "ocs-operator"
 	monLabelKey                            = "app"
 	monLabelValue                          = "managed-ocs"
+	osdLabelKey                            = "app"
+	osdLabelValue                          = "rook-ceph-osd"
+	osdResourcesKey                        = "osd"
 	rookConfigMapName                      = "rook-ceph-operator-config"
+	storageClusterTemplateKey              = "storagecluster.yaml"
 	k8sMetricsServiceMonitorName           = "k8s-metrics-service-monitor"
 	grafanaDatasourceSecretName            = "grafana-datasources"
 	grafanaDatasourceSecretKey             = "prometheus.yaml"
 	k8sMetricsServiceMonitorAuthSecretName = "k8s-metrics-service-monitor-auth"
 	openshiftMonitoringNamespace           = "openshift-monitoring"
+	kmsConnectionDetailsConfigMapName      = "ocs-kms-connection-details"
+	kmsProvider                            = "vault"
+	autoSizingDeviceClass                  = "ssd"
+)
+
+const (
+	eventReasonStorageClusterCreated         = "StorageClusterCreated"
+	eventReasonStorageClusterUpdated         = "StorageClusterUpdated"
+	eventReasonReconcileStrategyChanged      = "ReconcileStrategyChanged"
+	eventReasonReadinessChanged              = "ReadinessChanged"
+	eventReasonReconcileFailed               = "ReconcileFailed"
+	eventReasonStorageClusterPhaseTimeout    = "StorageClusterPhaseTimeout"
+	eventReasonStorageClusterAdoptionRefused = "StorageClusterAdoptionRefused"
+	eventReasonPreflightFailed               = "PreflightFailed"
 )
 
 // ManagedOCSReconciler reconciles a ManagedOCS object
@@ -85,6 +135,10 @@ type ManagedOCSReconciler struct {
 	Log                logr.Logger
 	Scheme             *runtime.Scheme
 
+	// Namespace is the namespace the deployer is running in and watching, it has to exist
+	// when the controller is set up
+	Namespace string
+
 	AddonParamSecretName         string
 	AddonConfigMapName           string
 	AddonConfigMapDeleteLabelKey string
@@ -93,11 +147,29 @@ type ManagedOCSReconciler struct {
 	DeadMansSnitchSecretName     string
 	SOPEndpoint                  string
 
-	ctx                                context.Context
+	// LogLevel, when set, is adjusted to the log level configured in the ManagedOCS spec or,
+	// if not set there, in the OperatorConfig
+	LogLevel *zap.AtomicLevel
+
+	// LivenessTimeout is the time a reconcile can run before LivenessCheck fails. Defaults
+	// to DefaultLivenessTimeout
+	LivenessTimeout time.Duration
+
+	// OperatorVersion is the build version of the operator, recorded in the status of the
+	// reconciled ManagedOCS resources
+	OperatorVersion string
+
+	// The context of a reconcile is passed to the methods as their first argument, do not
+	// store it in the reconciler, where it would outlive the reconcile
+	recorder                           record.EventRecorder
+	liveness                           reconcileLiveness
 	managedOCS                         *v1.ManagedOCS
 	storageCluster                     *ocsv1.StorageCluster
+	storageClusterTemplateRef          *corev1.LocalObjectReference
+	storageClusterTemplateVersion      string
 	prometheus                         *promv1.Prometheus
 	dmsRule                            *promv1.PrometheusRule
+	storageAlertsRule                  *promv1.PrometheusRule
 	alertmanager                       *promv1.Alertmanager
 	pagerdutySecret                    *corev1.Secret
 	deadMansSnitchSecret               *corev1.Secret
@@ -106,6 +178,10 @@ type ManagedOCSReconciler struct {
 	k8sMetricsServiceMonitorAuthSecret *corev1.Secret
 	namespace                          string
 	reconcileStrategy                  v1.ReconcileStrategy
+	operatorConfig                     v1.OperatorConfigSpec
+	autoSizedDeviceSetCount            int
+	deferredStorageClusters            []string
+	adoptionRefusedStorageClusters     []string
 }
 
 // Add necessary rbac permissions for managedocs finalizer in order to set blockOwnerDeletion.
@@ -113,6 +189,8 @@ type ManagedOCSReconciler struct {
 // +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=managedocs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=storageclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=ocsinitializations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=operatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ocs.openshift.io,namespace=system,resources=tuningpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=system,resources={alertmanagers,prometheuses,alertmanagerconfigs},verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=system,resources=prometheusrules,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=system,resources=podmonitors,verbs=get;list;watch;update;patch
@@ -121,15 +199,44 @@ type ManagedOCSReconciler struct {
 // +kubebuilder:rbac:groups=operators.coreos.com,namespace=system,resources=subscriptions,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=operators.coreos.com,namespace=system,resources=clusterserviceversions,verbs=get;list;watch;delete;update;patch
 // +kubebuilder:rbac:groups="apps",namespace=system,resources=statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=system,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=system,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources={persistentvolumeclaims,secrets},verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="storage.k8s.io",resources=storageclasses,verbs=get;list;watch
 
 // SetupWithManager creates an setup a ManagedOCSReconciler to work with the provided manager
 func (r *ManagedOCSReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := validateNamespace(mgr.GetAPIReader(), r.Namespace); err != nil {
+		return err
+	}
+	if err := registerMetrics(); err != nil {
+		return err
+	}
+	r.recorder = mgr.GetEventRecorderFor("managedocs-controller")
+
+	rateLimiter, err := newManagedOCSRateLimiter()
+	if err != nil {
+		return err
+	}
 	ctrlOptions := controller.Options{
 		MaxConcurrentReconciles: 1,
+		RateLimiter:             rateLimiter,
 	}
 	managedOCSPredicates := builder.WithPredicates(
-		predicate.GenerationChangedPredicate{},
+		predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			// Resume reconciling as soon as the pause or dry run annotation is removed
+			predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldAnnotations := e.MetaOld.GetAnnotations()
+					newAnnotations := e.MetaNew.GetAnnotations()
+					return oldAnnotations[ReconcilePauseAnnotation] != newAnnotations[ReconcilePauseAnnotation] ||
+						oldAnnotations[DryRunAnnotation] != newAnnotations[DryRunAnnotation]
+				},
+			},
+		),
 	)
 	secretPredicates := builder.WithPredicates(
 		predicate.NewPredicateFuncs(
@@ -149,7 +256,11 @@ func (r *ManagedOCSReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					if _, ok := meta.GetLabels()[r.AddonConfigMapDeleteLabelKey]; ok {
 						return true
 					}
-				} else if name == rookConfigMapName {
+				} else if name == rookConfigMapName || name == kmsConnectionDetailsConfigMapName ||
+					name == rookConfigOverrideName {
+					return true
+				} else if meta.GetLabels()[StorageClusterTemplateLabel] == "true" ||
+					meta.GetLabels()[AlertingRulesLabel] == "true" {
 					return true
 				}
 				return false
@@ -188,6 +299,13 @@ func (r *ManagedOCSReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			},
 		),
 	)
+	operatorConfigPredicates := builder.WithPredicates(
+		predicate.NewPredicateFuncs(
+			func(meta metav1.Object, _ runtime.Object) bool {
+				return meta.GetName() == operatorConfigName
+			},
+		),
+	)
 	enqueueManangedOCSRequest := handler.EnqueueRequestsFromMapFunc{
 		ToRequests: handler.ToRequestsFunc(
 			func(obj handler.MapObject) []reconcile.Request {
@@ -253,52 +371,138 @@ func (r *ManagedOCSReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&enqueueManangedOCSRequest,
 			ocsCSVPredicates,
 		).
+		Watches(
+			&source.Kind{Type: &v1.OperatorConfig{}},
+			&enqueueManangedOCSRequest,
+			operatorConfigPredicates,
+		).
+		Watches(
+			&source.Kind{Type: &v1.TuningPolicy{}},
+			&enqueueManangedOCSRequest,
+		).
 
 		// Create the controller
 		Complete(r)
 }
 
+// validateNamespace makes sure the namespace is set and exists, using a non cached reader as
+// the manager caches are not started yet
+func validateNamespace(reader client.Reader, namespace string) error {
+	if namespace == "" {
+		return fmt.Errorf("the namespace to watch must be set")
+	}
+	ns := &corev1.Namespace{}
+	if err := reader.Get(context.Background(), client.ObjectKey{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("the namespace to watch %q does not exist", namespace)
+		}
+		return fmt.Errorf("unable to get the namespace to watch %q: %w", namespace, err)
+	}
+	return nil
+}
+
 // Reconcile changes to all owned resource based on the infromation provided by the ManagedOCS resource
-func (r *ManagedOCSReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+func (r *ManagedOCSReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
 	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
 	log.Info("Starting reconcile for ManagedOCS")
+	r.liveness.start(time.Now())
+	defer r.liveness.done()
+
+	// Record the total duration of the reconcile, labeled by its outcome. The outcome is set once
+	// the reconcile succeeded, failed and panicked reconciles are recorded as errors
+	start := time.Now()
+	outcome := reconcileResultError
+	defer func() {
+		reconcileDuration.WithLabelValues(metricsControllerName, outcome).Observe(time.Since(start).Seconds())
+	}()
 
 	// Initalize the reconciler properties from the request
+	ctx := context.Background()
+	// A panic only fails the reconcile of this ManagedOCS resource instead of the operator
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result, err = r.recoverReconcilePanic(ctx, recovered), nil
+		}
+	}()
 	r.initReconciler(req)
 
+	// Load the operational parameters, falling back to the built-in behavior on failures
+	if err := r.loadOperatorConfig(ctx); err != nil {
+		r.Log.Error(err, "Unable to load the OperatorConfig, using defaults")
+	}
+
 	// Load the managed ocs resource (input)
-	if err := r.get(r.managedOCS); err != nil {
+	if err := retryTransient(ctx, func() error { return r.get(ctx, r.managedOCS) }); err != nil {
 		if errors.IsNotFound(err) {
 			r.Log.V(-1).Info("ManagedOCS resource not found")
 		} else {
+			reconcileErrors.WithLabelValues(reconcilePhaseLoad).Inc()
 			return ctrl.Result{}, err
 		}
 	}
+	primary := getManagedStorageClusters(r.managedOCS)[0]
+	r.storageCluster.Name = primary.Name
+	r.storageClusterTemplateRef = primary.StorageClusterTemplate
 
 	// Run the reconcile phases
-	result, err := r.reconcilePhases()
+	result, err = r.reconcilePhases(ctx)
 	if err != nil {
+		reconcileErrors.WithLabelValues(reconcilePhaseReconcilePhases).Inc()
 		r.Log.Error(err, "An error was encountered during reconcilePhases")
+		r.recordEvent(corev1.EventTypeWarning, eventReasonReconcileFailed, "Reconcile failed: %v", err)
+	}
+
+	// Transient failures are retried with an exponential backoff, recorded in the status
+	// so it keeps growing across reconciles until a reconcile succeeds
+	backoff := time.Duration(0)
+	if isRetriableError(err) {
+		backoff = nextRetryBackoff(time.Duration(r.managedOCS.Status.RetryAfterSeconds) * time.Second)
+	}
+	r.managedOCS.Status.RetryAfterSeconds = int64(backoff / time.Second)
+	if r.managedOCS.UID != "" {
+		setReconcileFailedCondition(r.managedOCS, err)
+	}
+	if err == nil {
+		r.managedOCS.Status.LastSyncTime = metav1.Now()
+	}
+	if err == nil && !r.isReconcilePaused() && !isSpecPaused(r.managedOCS) && !isMaintenanceActive(r.managedOCS) {
+		now := metav1.Now()
+		r.managedOCS.Status.LastReconcileTime = &now
+		r.managedOCS.Status.ReconciledByVersion = r.OperatorVersion
+		r.managedOCS.Status.ObservedGeneration = r.managedOCS.Generation
 	}
 
 	// Ensure status is updated once even on failed reconciles
 	var statusErr error
 	if r.managedOCS.UID != "" {
-		statusErr = r.Client.Status().Update(r.ctx, r.managedOCS)
+		statusErr = retryTransient(ctx, func() error { return r.Client.Status().Update(ctx, r.managedOCS) })
+		if statusErr != nil {
+			reconcileErrors.WithLabelValues(reconcilePhaseStatusUpdate).Inc()
+		}
 	}
 
 	// Reconcile errors have priority to status update errors
 	if err != nil {
+		if backoff > 0 {
+			r.Log.Info("Retrying after a transient error", "backoff", backoff)
+			return ctrl.Result{Requeue: true, RequeueAfter: jitterRetryBackoff(backoff)}, nil
+		}
 		return ctrl.Result{}, err
 	} else if statusErr != nil {
+		if isRetriableError(statusErr) {
+			return ctrl.Result{Requeue: true, RequeueAfter: jitterRetryBackoff(initialRetryBackoff)}, nil
+		}
 		return ctrl.Result{}, statusErr
 	} else {
+		outcome = getReconcileOutcome(result)
+		if !result.Requeue && result.RequeueAfter == 0 && !isSpecPaused(r.managedOCS) {
+			result.RequeueAfter = r.getRequeueInterval()
+		}
 		return result, nil
 	}
 }
 
 func (r *ManagedOCSReconciler) initReconciler(req ctrl.Request) {
-	r.ctx = context.Background()
 	r.namespace = req.NamespacedName.Namespace
 
 	r.managedOCS = &v1.ManagedOCS{}
@@ -306,7 +510,6 @@ func (r *ManagedOCSReconciler) initReconciler(req ctrl.Request) {
 	r.managedOCS.Namespace = r.namespace
 
 	r.storageCluster = &ocsv1.StorageCluster{}
-	r.storageCluster.Name = storageClusterName
 	r.storageCluster.Namespace = r.namespace
 
 	r.prometheus = &promv1.Prometheus{}
@@ -317,6 +520,10 @@ func (r *ManagedOCSReconciler) initReconciler(req ctrl.Request) {
 	r.dmsRule.Name = dmsRuleName
 	r.dmsRule.Namespace = r.namespace
 
+	r.storageAlertsRule = &promv1.PrometheusRule{}
+	r.storageAlertsRule.Name = storageAlertsRuleName
+	r.storageAlertsRule.Namespace = r.namespace
+
 	r.alertmanager = &promv1.Alertmanager{}
 	r.alertmanager.Name = alertmanagerName
 	r.alertmanager.Namespace = r.namespace
@@ -343,87 +550,176 @@ func (r *ManagedOCSReconciler) initReconciler(req ctrl.Request) {
 
 }
 
-func (r *ManagedOCSReconciler) reconcilePhases() (reconcile.Result, error) {
+func (r *ManagedOCSReconciler) reconcilePhases(ctx context.Context) (reconcile.Result, error) {
+	// The log level and the debug mode are applied even while the reconcile is paused, to
+	// help debugging it
+	r.applySpecLogLevel()
+	defer r.applyDebugMode()()
+	r.Log.V(1).Info("Reconciling phases", "generation", r.managedOCS.Generation,
+		"observedGeneration", r.managedOCS.Status.ObservedGeneration, "resourceVersion", r.managedOCS.ResourceVersion)
+
+	// Paused ManagedOCS resources are left alone, including their deletion, until the
+	// pause annotation is removed
+	if r.isReconcilePaused() {
+		r.Log.Info("reconcile is paused, skipping", "annotation", ReconcilePauseAnnotation)
+		return ctrl.Result{}, nil
+	}
+
+	// Keep a trace of the conditions overwritten by this reconcile
+	previousConditions := make([]metav1.Condition, len(r.managedOCS.Status.Conditions))
+	copy(previousConditions, r.managedOCS.Status.Conditions)
+	defer recordConditionHistory(r.managedOCS, previousConditions)
+
+	// Nothing is written while spec.paused or spec.maintenanceMode is set, and the reconcile is
+	// not requeued. Unsetting it changes the generation, which triggers a full reconcile
+	wasPaused := isSpecPaused(r.managedOCS)
+	if checkSpecPaused(r.managedOCS) {
+		condition := meta.FindStatusCondition(r.managedOCS.Status.Conditions, v1.ConditionPaused)
+		r.Log.Info("reconcile is paused, skipping", "reason", condition.Reason)
+		if !wasPaused {
+			r.recordEvent(corev1.EventTypeNormal, eventReasonReconcilePaused, condition.Message)
+		}
+		return ctrl.Result{}, nil
+	} else if wasPaused {
+		r.recordEvent(corev1.EventTypeNormal, eventReasonReconcileResumed, "Reconciliation resumed, running a full reconcile")
+	}
+
+	// Nothing is written while the maintenance window is open, a full reconcile is run once
+	// it closes
+	if remaining := checkMaintenanceWindow(r.managedOCS, time.Now()); remaining > 0 {
+		r.Log.Info("maintenance window is active, skipping", "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// The spec of a restored ManagedOCS is applied before anything is reconciled, the spec
+	// update triggers the next reconcile
+	if restored, err := r.restoreFromBackup(ctx); err != nil {
+		return ctrl.Result{}, err
+	} else if restored {
+		return ctrl.Result{}, nil
+	}
+
 	// Uninstallation depends on the status of the components.
 	// We are checking the uninstallation condition before getting the component status
 	// to mitigate scenarios where changes to the component status occurs while the uninstallation logic is running.
-	initiateUninstall := r.checkUninstallCondition()
+	initiateUninstall := r.checkUninstallCondition(ctx)
 	// Update the status of the components
-	r.updateComponentStatus()
-
-	if !r.managedOCS.DeletionTimestamp.IsZero() {
-		if r.verifyComponentsDoNotExist() {
-			r.Log.Info("removing finalizer from the ManagedOCS resource")
-			r.managedOCS.SetFinalizers(utils.Remove(r.managedOCS.GetFinalizers(), ManagedOCSFinalizer))
-			if err := r.Client.Update(r.ctx, r.managedOCS); err != nil {
-				return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from managedOCS: %v", err)
-			}
-			r.Log.Info("finallizer removed successfully")
-
+	wasReady := areComponentsReady(&r.managedOCS.Status)
+	r.updateComponentStatus(ctx)
+	if ready := areComponentsReady(&r.managedOCS.Status); ready != wasReady {
+		if ready {
+			r.recordEvent(corev1.EventTypeNormal, eventReasonReadinessChanged, "All managed components are ready")
 		} else {
-			// Storage cluster needs to be deleted before we delete the CSV so we can not leave it to the
-			// k8s garbage collector to delete it
-			r.Log.Info("deleting storagecluster")
-			if err := r.delete(r.storageCluster); err != nil {
-				return ctrl.Result{}, fmt.Errorf("unable to delete storagecluster: %v", err)
-			}
+			r.recordEvent(corev1.EventTypeWarning, eventReasonReadinessChanged, "Some managed components are not ready")
 		}
+	}
+
+	if !r.managedOCS.DeletionTimestamp.IsZero() {
+		return r.reconcileDeletion(ctx, initiateUninstall)
 
 	} else if r.managedOCS.UID != "" {
 		if !utils.Contains(r.managedOCS.GetFinalizers(), ManagedOCSFinalizer) {
 			r.Log.V(-1).Info("finalizer missing on the managedOCS resource, adding...")
 			r.managedOCS.SetFinalizers(append(r.managedOCS.GetFinalizers(), ManagedOCSFinalizer))
-			if err := r.update(r.managedOCS); err != nil {
-				return ctrl.Result{}, fmt.Errorf("failed to update managedOCS with finalizer: %v", err)
+			if err := r.update(ctx, r.managedOCS); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update managedOCS with finalizer: %w", err)
 			}
 		}
 
-		// Find the effective reconcile strategy
-		r.reconcileStrategy = v1.ReconcileStrategyStrict
-		if strings.EqualFold(string(r.managedOCS.Spec.ReconcileStrategy), string(v1.ReconcileStrategyNone)) {
-			r.reconcileStrategy = v1.ReconcileStrategyNone
-		}
+		r.reconcileStrategy = getReconcileStrategy(r.managedOCS.Spec.ReconcileStrategy)
 
 		// Reconcile the different resources
-		if err := r.reconcileRookCephOperatorConfig(); err != nil {
+		if err := r.reconcileRookCephOperatorConfig(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.autoSizeStorageDeviceSets(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		// Do not touch the storage cluster until the cluster can host it, OCS would
+		// otherwise loop over errors on a storage cluster that cannot be deployed
+		if passed, err := r.runPreflightChecks(ctx); err != nil {
+			return ctrl.Result{}, err
+		} else if !passed {
+			r.Log.Info("preflight checks failed, skipping storage cluster reconciliation")
+			return ctrl.Result{RequeueAfter: preflightRetryInterval}, nil
+		}
+		if err := r.checkOCSSubscription(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if ready, err := r.checkOCSCSVReady(ctx); err != nil {
+			return ctrl.Result{}, err
+		} else if !ready {
+			r.Log.Info("OCS CSV is not ready, skipping storage cluster reconciliation")
+			return ctrl.Result{RequeueAfter: csvRetryInterval}, nil
+		}
+		if supported, err := r.checkOCSVersion(ctx); err != nil {
+			return ctrl.Result{}, err
+		} else if !supported {
+			r.Log.Info("OCS version mismatch, skipping storage cluster reconciliation")
+			return ctrl.Result{}, nil
+		}
+		if err := r.reconcileKMSConnectionDetails(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileStorageClusters(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileStorageQuotas(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileBackpressure(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileTuningPolicies(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileStorageCluster(); err != nil {
+		if err := r.reconcileCustomCephConf(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileOCSCSV(); err != nil {
+		r.checkStorageClusterPhaseTimeout()
+		if err := r.reconcileOCSCSV(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcilePrometheus(); err != nil {
+		if err := r.reconcilePrometheus(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileAlertmanager(); err != nil {
+		if err := r.reconcileAlertmanager(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileAlertmanagerConfig(); err != nil {
+		if err := r.reconcileAlertmanagerConfig(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileK8SMetricsServiceMonitorAuthSecret(); err != nil {
+		if err := r.reconcileK8SMetricsServiceMonitorAuthSecret(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileK8SMetricsServiceMonitor(); err != nil {
+		if err := r.reconcileK8SMetricsServiceMonitor(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileMonitoringResources(); err != nil {
+		if err := r.reconcileOCSMetricsServiceMonitors(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileDMSPrometheusRule(); err != nil {
+		if err := r.reconcileStorageAlertsPrometheusRule(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
-		if err := r.reconcileOCSInitialization(); err != nil {
+		if err := r.reconcileMonitoringResources(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileDMSPrometheusRule(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileOCSInitialization(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
 
+		if r.managedOCS.Status.ReconcileStrategy != r.reconcileStrategy {
+			r.recordEvent(corev1.EventTypeNormal, eventReasonReconcileStrategyChanged,
+				"Reconcile strategy changed from %q to %q", r.managedOCS.Status.ReconcileStrategy, r.reconcileStrategy)
+		}
 		r.managedOCS.Status.ReconcileStrategy = r.reconcileStrategy
 
 		// Check if we need and can uninstall
 		if initiateUninstall && r.areComponentsReadyForUninstall() {
-			found, err := r.findOCSVolumeClaims()
+			found, err := r.findOCSVolumeClaims(ctx)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
@@ -433,41 +729,139 @@ func (r *ManagedOCSReconciler) reconcilePhases() (reconcile.Result, error) {
 			}
 
 			r.Log.Info("starting OCS uninstallation - deleting managedocs")
-			if err := r.delete(r.managedOCS); err != nil {
-				return ctrl.Result{}, fmt.Errorf("unable to delete managedocs: %v", err)
+			if err := r.delete(ctx, r.managedOCS); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to delete managedocs: %w", err)
 			}
 		}
 
+		// Storage nodes are not watched, they are listed again while a scale-up waits for them
+		if r.managedOCS.Status.ScalingPhase == v1.ScalingPhasePending {
+			return ctrl.Result{RequeueAfter: scalingRetryInterval}, nil
+		}
+		if len(r.deferredStorageClusters) > 0 {
+			return ctrl.Result{RequeueAfter: getRecoveryCheckInterval(r.managedOCS)}, nil
+		}
+
 	} else if initiateUninstall {
-		return ctrl.Result{}, r.removeOLMComponents()
+		return ctrl.Result{}, r.removeOLMComponents(ctx)
 	}
 
 	return ctrl.Result{}, nil
 }
 
-func (r *ManagedOCSReconciler) updateComponentStatus() {
-	// Getting the status of the StorageCluster component.
-	scStatus := &r.managedOCS.Status.Components.StorageCluster
-	if err := r.get(r.storageCluster); err == nil {
-		if r.storageCluster.Status.Phase == "Ready" {
-			scStatus.State = v1.ComponentReady
-		} else {
-			scStatus.State = v1.ComponentPending
+// getReconcileStrategy returns the effective reconcile strategy. An empty or unknown strategy
+// defaults to strict
+func getReconcileStrategy(strategy v1.ReconcileStrategy) v1.ReconcileStrategy {
+	if strings.EqualFold(string(strategy), string(v1.ReconcileStrategyNone)) {
+		return v1.ReconcileStrategyNone
+	} else if strings.EqualFold(string(strategy), string(v1.ReconcileStrategyForce)) {
+		return v1.ReconcileStrategyForce
+	}
+	return v1.ReconcileStrategyStrict
+}
+
+// reconcileDeletion drives the teardown of the managed components while the ManagedOCS resource
+// is being deleted. With the Retain reclaim policy, the StorageClusters are released and the
+// finalizer is removed right away. With the Delete reclaim policy, or when the addon is being
+// uninstalled, the finalizer is only removed once the StorageClusters are gone and all OSD pods
+// have terminated, so no PVCs or OSDs are left behind without an operator to manage them.
+func (r *ManagedOCSReconciler) reconcileDeletion(ctx context.Context, uninstalling bool) (reconcile.Result, error) {
+	if err := r.pruneStorageQuotas(ctx, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.deleteBackpressureWebhookConfig(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The reclaim policy defaults to retain. The addon uninstall removes the OCS operator next,
+	// so the StorageClusters are always deleted then
+	if !uninstalling && r.managedOCS.Spec.ReclaimPolicy != v1.ReclaimPolicyDelete {
+		if err := r.releaseStorageClusters(ctx); err != nil {
+			return ctrl.Result{}, err
 		}
-	} else if errors.IsNotFound(err) {
-		scStatus.State = v1.ComponentNotFound
-	} else {
-		r.Log.V(-1).Info("error getting StorageCluster, setting compoment status to Unknown")
-		scStatus.State = v1.ComponentUnknown
+		return r.removeFinalizer(ctx)
+	}
+
+	if !r.verifyComponentsDoNotExist() {
+		// Storage cluster needs to be deleted before we delete the CSV so we can not leave it to the
+		// k8s garbage collector to delete it
+		r.Log.Info("deleting storageclusters")
+		for _, managedStorageCluster := range getManagedStorageClusters(r.managedOCS) {
+			sc := &ocsv1.StorageCluster{}
+			sc.Name = managedStorageCluster.Name
+			sc.Namespace = r.namespace
+			if err := r.delete(ctx, sc); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to delete storagecluster %v: %w", sc.Name, err)
+			}
+		}
+		// The StorageClusterWatcher reports the storage cluster as not found once it is gone
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
+	found, err := r.findOSDPods(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if found {
+		r.Log.Info("waiting for OSD pods to terminate before removing the finalizer")
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+	return r.removeFinalizer(ctx)
+}
+
+// releaseStorageClusters removes the ManagedOCS owner reference from the managed storage
+// clusters, so they are not garbage collected with the ManagedOCS resource
+func (r *ManagedOCSReconciler) releaseStorageClusters(ctx context.Context) error {
+	r.Log.Info("releasing storageclusters", "reclaimPolicy", v1.ReclaimPolicyRetain)
+	for _, managedStorageCluster := range getManagedStorageClusters(r.managedOCS) {
+		sc := &ocsv1.StorageCluster{}
+		sc.Name = managedStorageCluster.Name
+		sc.Namespace = r.namespace
+		if err := r.get(ctx, sc); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("unable to get storagecluster %v: %w", sc.Name, err)
+		}
+		if !isOwnedByManagedOCS(sc) {
+			continue
+		}
+		patch := client.MergeFrom(sc.DeepCopy())
+		var ownerRefs []metav1.OwnerReference
+		for _, ownerRef := range sc.GetOwnerReferences() {
+			if ownerRef.UID != r.managedOCS.UID {
+				ownerRefs = append(ownerRefs, ownerRef)
+			}
+		}
+		sc.SetOwnerReferences(ownerRefs)
+		if err := r.Client.Patch(ctx, sc, patch); err != nil {
+			return fmt.Errorf("unable to release storagecluster %v: %w", sc.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *ManagedOCSReconciler) removeFinalizer(ctx context.Context) (reconcile.Result, error) {
+	r.Log.Info("removing finalizer from the ManagedOCS resource")
+	r.managedOCS.SetFinalizers(utils.Remove(r.managedOCS.GetFinalizers(), ManagedOCSFinalizer))
+	if err := r.Client.Update(ctx, r.managedOCS); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from managedOCS: %w", err)
+	}
+	r.Log.Info("finallizer removed successfully")
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ManagedOCSReconciler) updateComponentStatus(ctx context.Context) {
+	// The status of the StorageCluster component is owned by the StorageClusterWatcher
+
 	// Getting the status of the Prometheus component.
 	promStatus := &r.managedOCS.Status.Components.Prometheus
-	if err := r.get(r.prometheus); err == nil {
+	if err := r.get(ctx, r.prometheus); err == nil {
 		promStatefulSet := &appsv1.StatefulSet{}
 		promStatefulSet.Namespace = r.namespace
 		promStatefulSet.Name = fmt.Sprintf("prometheus-%s", prometheusName)
-		if err := r.get(promStatefulSet); err == nil {
+		if err := r.get(ctx, promStatefulSet); err == nil {
 			desiredReplicas := int32(1)
 			if r.prometheus.Spec.Replicas != nil {
 				desiredReplicas = *r.prometheus.Spec.Replicas
@@ -489,11 +883,11 @@ func (r *ManagedOCSReconciler) updateComponentStatus() {
 
 	// Getting the status of the Alertmanager component.
 	amStatus := &r.managedOCS.Status.Components.Alertmanager
-	if err := r.get(r.alertmanager); err == nil {
+	if err := r.get(ctx, r.alertmanager); err == nil {
 		amStatefulSet := &appsv1.StatefulSet{}
 		amStatefulSet.Namespace = r.namespace
 		amStatefulSet.Name = fmt.Sprintf("alertmanager-%s", alertmanagerName)
-		if err := r.get(amStatefulSet); err == nil {
+		if err := r.get(ctx, amStatefulSet); err == nil {
 			desiredReplicas := int32(1)
 			if r.alertmanager.Spec.Replicas != nil {
 				desiredReplicas = *r.alertmanager.Spec.Replicas
@@ -523,36 +917,604 @@ func (r *ManagedOCSReconciler) verifyComponentsDoNotExist() bool {
 	return false
 }
 
-func (r *ManagedOCSReconciler) reconcileStorageCluster() error {
-	r.Log.Info("Reconciling StorageCluster")
+// reconcileStorageClusters reconciles each of the storage clusters managed by the ManagedOCS
+// resource. The primary storage cluster is left loaded for the following phases
+func (r *ManagedOCSReconciler) reconcileStorageClusters(ctx context.Context) error {
+	storageClusters := getManagedStorageClusters(r.managedOCS)
+	primary := r.storageCluster
+	r.deferredStorageClusters = nil
+	r.adoptionRefusedStorageClusters = nil
+	for i := range storageClusters {
+		if i == 0 {
+			r.storageCluster = primary
+		} else {
+			r.storageCluster = &ocsv1.StorageCluster{}
+			r.storageCluster.Name = storageClusters[i].Name
+			r.storageCluster.Namespace = r.namespace
+		}
+		r.storageClusterTemplateRef = storageClusters[i].StorageClusterTemplate
+		if err := r.reconcileStorageCluster(ctx); err != nil {
+			err = fmt.Errorf("unable to reconcile StorageCluster %v: %w", storageClusters[i].Name, err)
+			// Keep the more specific error of the failure, e.g. an invalid template
+			if _, ok := deployererrors.AsReconcileError(err); ok {
+				return err
+			}
+			return deployererrors.NewStorageClusterError(storageClusters[i].Name, "ReconcileFailed", isRetriableError(err), err)
+		}
+	}
+	r.storageCluster = primary
+	r.storageClusterTemplateRef = storageClusters[0].StorageClusterTemplate
+	r.updateUpdateDeferredCondition()
+	r.updateAdoptionRefusedCondition()
+	return nil
+}
+
+// isPrimaryStorageCluster checks whether the loaded storage cluster is the primary one. Only
+// the primary storage cluster is reported in the ManagedOCS status
+func (r *ManagedOCSReconciler) isPrimaryStorageCluster() bool {
+	return r.storageCluster.Name == getStorageClusterName(r.managedOCS)
+}
+
+func (r *ManagedOCSReconciler) reconcileStorageCluster(ctx context.Context) error {
+	r.Log.Info("Reconciling StorageCluster", "name", r.storageCluster.Name)
 
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.storageCluster, func() error {
-		if err := r.own(r.storageCluster); err != nil {
-			return err
+	// Do not take over a storage cluster that was created outside of the deployer,
+	// unless explicitly requested to
+	if err := r.get(ctx, r.storageCluster); err == nil {
+		if !isOwnedByManagedOCS(r.storageCluster) {
+			if !r.managedOCS.Spec.AdoptExistingCluster {
+				r.refuseStorageClusterAdoption()
+				return nil
+			}
+			if r.isDryRun() {
+				r.Log.Info("dry run, skipping StorageCluster adoption", "name", r.storageCluster.Name)
+				return nil
+			}
+			return r.adoptStorageCluster(ctx)
 		}
 
-		// Handle only strict mode reconciliation
-		if r.reconcileStrategy == v1.ReconcileStrategyStrict {
-			// Get an instance of the desired state
-			desired := templates.StorageClusterTemplate.DeepCopy()
-			if err := r.updateStorageClusterFromAddonParamsSecret(desired); err != nil {
+		// Reconcile strategy none only writes the storage cluster to create or adopt it. Once
+		// the current ManagedOCS generation was reconciled, only the spec drift is left to report
+		if r.reconcileStrategy == v1.ReconcileStrategyNone &&
+			r.managedOCS.Status.ObservedGeneration == r.managedOCS.Generation {
+			r.Log.Info("ManagedOCS generation already reconciled, skipping StorageCluster update")
+			if !r.isPrimaryStorageCluster() {
+				return nil
+			}
+			r.setStorageClusterRef()
+			specHash, err := hashStorageClusterSpec(&r.storageCluster.Spec)
+			if err != nil {
 				return err
 			}
+			r.updateSpecDriftCondition(specHash)
+			return nil
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	// Dry runs only report the changes the deployer would make to the storage cluster
+	if r.isDryRun() {
+		return r.reconcileStorageClusterDryRun(ctx)
+	}
+
+	// CreateOrUpdate compares the mutated storage cluster with the one read from the
+	// cluster, so enforcing an unchanged template does not write to the API server
+	result, err := ctrl.CreateOrUpdate(ctx, r.Client, r.storageCluster, func() error {
+		return r.setDesiredStorageCluster(ctx, r.storageCluster)
+	})
+	if err != nil {
+		return err
+	}
+	switch result {
+	case controllerutil.OperationResultCreated:
+		r.recordEvent(corev1.EventTypeNormal, eventReasonStorageClusterCreated, "StorageCluster %v created", r.storageCluster.Name)
+	case controllerutil.OperationResultUpdated:
+		r.recordEvent(corev1.EventTypeNormal, eventReasonStorageClusterUpdated, "StorageCluster %v updated", r.storageCluster.Name)
+	}
+	if !r.isPrimaryStorageCluster() {
+		return nil
+	}
+	r.setStorageClusterRef()
+	r.managedOCS.Status.AppliedTemplateVersion = r.storageClusterTemplateVersion
+
+	// Keep track of the applied spec, so changes made to the storage cluster while
+	// the deployer does not enforce its spec can be surfaced
+	specHash, err := hashStorageClusterSpec(&r.storageCluster.Spec)
+	if err != nil {
+		return err
+	}
+	if r.reconcileStrategy == v1.ReconcileStrategyNone {
+		r.updateSpecDriftCondition(specHash)
+	} else {
+		r.managedOCS.Status.StorageClusterSpecHash = specHash
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionSpecDrift)
+	}
+
+	return nil
+}
+
+// setStorageClusterRef records the name of the storage cluster managed by the deployer
+func (r *ManagedOCSReconciler) setStorageClusterRef() {
+	r.managedOCS.Status.StorageClusterRef = &corev1.LocalObjectReference{Name: r.storageCluster.Name}
+}
+
+// setDesiredStorageCluster mutates sc into the desired state of the storage cluster, according
+// to the reconcile strategy
+func (r *ManagedOCSReconciler) setDesiredStorageCluster(ctx context.Context, sc *ocsv1.StorageCluster) error {
+	if err := r.own(sc); err != nil {
+		return err
+	}
+	r.storageClusterTemplateVersion = r.managedOCS.Status.AppliedTemplateVersion
+
+	// Reconcile strategy none leaves the storage cluster spec untouched
+	if r.reconcileStrategy == v1.ReconcileStrategyNone {
+		return nil
+	}
+	// Spec changes are not applied while Ceph is recovering, the storage cluster is checked
+	// again at the recovery check interval
+	if isStorageClusterRecovering(sc) {
+		r.deferStorageClusterUpdate(sc)
+		return nil
+	}
+	// The annotations and labels of the ManagedOCS spec take precedence over the ones already set
+	for key, value := range r.managedOCS.Spec.StorageClusterAnnotations {
+		metav1.SetMetaDataAnnotation(&sc.ObjectMeta, key, value)
+	}
+	for key, value := range r.managedOCS.Spec.StorageClusterLabels {
+		utils.AddLabel(sc, key, value)
+	}
 
-			// Override storage cluster spec with desired spec from the template.
-			// We do not replace meta or status on purpose
-			r.storageCluster.Spec = desired.Spec
+	// Get an instance of the desired state
+	desired, err := r.getStorageClusterTemplate(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.updateStorageClusterFromAddonParamsSecret(ctx, desired); err != nil {
+		return err
+	}
+	// An explicit device set count or a storage profile overrides the template, the add-on
+	// size and auto sizing
+	if count := r.managedOCS.Spec.StorageDeviceSetCount; count != nil {
+		scaledCount, err := r.scaleStorageDeviceSets(ctx, sc, int(*count))
+		if err != nil {
+			return err
+		}
+		for i := range desired.Spec.StorageDeviceSets {
+			desired.Spec.StorageDeviceSets[i].Count = scaledCount
+		}
+	} else if profile, found := getStorageProfile(r.managedOCS); found {
+		applyStorageProfile(&desired.Spec, &sc.Spec, profile)
+	} else if r.autoSizedDeviceSetCount > 0 {
+		applyAutoSizedDeviceSetCount(&desired.Spec, &sc.Spec, r.autoSizedDeviceSetCount)
+	}
+	applyNodeSelector(&desired.Spec, r.managedOCS.Spec.NodeSelector)
+	applyTolerations(&desired.Spec, r.managedOCS.Spec.Tolerations)
+	applyPodAntiAffinity(&desired.Spec, r.managedOCS.Spec.AffinitySpec)
+	applyResourceRequirements(&desired.Spec, r.managedOCS.Spec.ResourceRequirements)
+	if network := r.managedOCS.Spec.NetworkSpec; network != nil {
+		desired.Spec.Network = network.DeepCopy()
+	}
+	// Encryption is enabled on top of the template when spec.encryptionConfig.enabled is set,
+	// the validating webhook rejects the updates disabling it once enabled
+	if encryption := r.managedOCS.Spec.EncryptionConfig; encryption != nil && encryption.Enabled {
+		desired.Spec.Encryption.Enable = true
+	}
+	if managedResources := r.managedOCS.Spec.ManagedResources; managedResources != nil {
+		if err := strategicMerge(&desired.Spec.ManagedResources, managedResources); err != nil {
+			return fmt.Errorf("unable to merge managed resources: %w", err)
+		}
+	}
+	// The credentials of the external Ceph cluster are checked by the validating webhook
+	if r.managedOCS.Spec.ExternalCephSecretRef != nil {
+		desired.Spec.ExternalStorage.Enable = true
+	}
+	// The passthrough spec is applied last, so it can override any of the fields above
+	if cephClusterSpec := r.managedOCS.Spec.CephClusterSpec; cephClusterSpec != nil && len(cephClusterSpec.Raw) > 0 {
+		if err := strategicMerge(&desired.Spec, cephClusterSpec); err != nil {
+			return fmt.Errorf("unable to merge ceph cluster spec: %w", err)
 		}
+	}
+	if !r.checkStorageClusterTemplateUpgrade(sc, desired) {
 		return nil
-	})
+	}
+	r.storageClusterTemplateVersion = desired.Annotations[templates.StorageClusterTemplateVersionAnnotation]
+	r.Log.V(1).Info("Desired storage cluster computed", "name", sc.Name, "reconcileStrategy", r.reconcileStrategy,
+		"templateVersion", r.storageClusterTemplateVersion, "storageDeviceSetCount", getStorageDeviceSetCount(&desired.Spec))
+
+	// Computing the diff is skipped unless debug logging is enabled
+	if r.Log.V(1).Enabled() {
+		defer r.logStorageClusterDiff(sc.DeepCopy(), sc)
+	}
+
+	if r.reconcileStrategy == v1.ReconcileStrategyForce {
+		// Merge the desired spec from the template into the storage cluster spec,
+		// keeping any field that is not set by the template
+		return mergeStorageClusterSpec(&sc.Spec, &desired.Spec)
+	}
+
+	// Override storage cluster spec with desired spec from the template.
+	// We do not replace meta or status on purpose
+	sc.Spec = desired.Spec
+	return nil
+}
+
+// logStorageClusterDiff logs the changes made to the storage cluster spec at debug level
+func (r *ManagedOCSReconciler) logStorageClusterDiff(current *ocsv1.StorageCluster, desired *ocsv1.StorageCluster) {
+	diff, err := utils.DiffStorageCluster(current, desired)
+	if err != nil {
+		r.Log.Error(err, "Unable to compute storage cluster spec diff")
+		return
+	}
+	if diff != "" {
+		r.Log.V(1).Info("Storage cluster spec changes", "diff", diff)
+	}
+}
+
+// restoreFromBackup replaces the spec of a new ManagedOCS resource with the spec saved in the
+// backup ConfigMap named by the restore annotation, and removes the annotation. The annotation
+// is ignored on a ManagedOCS resource that already manages a storage cluster.
+func (r *ManagedOCSReconciler) restoreFromBackup(ctx context.Context) (bool, error) {
+	backupName, found := r.managedOCS.Annotations[v1.RestoreFromBackupAnnotation]
+	if !found {
+		return false, nil
+	}
+	if r.managedOCS.Status.StorageClusterRef != nil {
+		r.Log.Info("ignoring restore annotation on a ManagedOCS managing a storage cluster", "backup", backupName)
+		return false, nil
+	}
+
+	backup := &corev1.ConfigMap{}
+	backup.Name = backupName
+	backup.Namespace = r.namespace
+	if err := r.get(ctx, backup); err != nil {
+		return false, fmt.Errorf("unable to get backup ConfigMap %v: %w", backupName, err)
+	}
+	data, ok := backup.Data[backupManagedOCSSpecKey]
+	if !ok {
+		return false, fmt.Errorf("backup ConfigMap %v does not contain a %v entry", backupName, backupManagedOCSSpecKey)
+	}
+	spec := v1.ManagedOCSSpec{}
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		return false, fmt.Errorf("invalid ManagedOCS spec in backup ConfigMap %v: %w", backupName, err)
+	}
+
+	r.Log.Info("restoring ManagedOCS spec from backup", "backup", backupName)
+	r.managedOCS.Spec = spec
+	delete(r.managedOCS.Annotations, v1.RestoreFromBackupAnnotation)
+	// The update response does not carry the status, which is updated at the end of the reconcile
+	status := r.managedOCS.Status.DeepCopy()
+	if err := r.update(ctx, r.managedOCS); err != nil {
+		return false, fmt.Errorf("unable to restore ManagedOCS from backup %v: %w", backupName, err)
+	}
+	r.managedOCS.Status = *status
+	return true, nil
+}
+
+// autoSizeStorageDeviceSets derives the storage device set count from the storage nodes when
+// auto sizing is enabled. Nodes are not watched, changes are picked up by the full reconciles
+func (r *ManagedOCSReconciler) autoSizeStorageDeviceSets(ctx context.Context) error {
+	r.autoSizedDeviceSetCount = 0
+	if !r.managedOCS.Spec.AutoSizing {
+		return nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.UnrestrictedClient.List(ctx, nodeList, client.HasLabels{sizing.StorageNodeLabelKey}); err != nil {
+		return fmt.Errorf("unable to list storage nodes: %w", err)
+	}
+	count, err := sizing.Calculate(nodeList.Items, autoSizingDeviceClass)
 	if err != nil {
+		return fmt.Errorf("unable to size storage device sets: %w", err)
+	}
+	r.Log.Info("Auto sized storage device sets", "count", count)
+	r.autoSizedDeviceSetCount = count
+	return nil
+}
+
+// applyAutoSizedDeviceSetCount sets the count of all the desired storage device sets, without
+// decreasing the count of the current storage device set of the same name
+func applyAutoSizedDeviceSetCount(desired *ocsv1.StorageClusterSpec, current *ocsv1.StorageClusterSpec, count int) {
+	for i := range desired.StorageDeviceSets {
+		deviceSet := &desired.StorageDeviceSets[i]
+		deviceSet.Count = count
+		for _, currentDeviceSet := range current.StorageDeviceSets {
+			if currentDeviceSet.Name == deviceSet.Name && currentDeviceSet.Count > deviceSet.Count {
+				deviceSet.Count = currentDeviceSet.Count
+			}
+		}
+	}
+}
+
+// reconcileStorageClusterDryRun computes the desired storage cluster and records its difference
+// with the current storage cluster in the ManagedOCS annotations, without writing the storage
+// cluster
+func (r *ManagedOCSReconciler) reconcileStorageClusterDryRun(ctx context.Context) error {
+	desired := r.storageCluster.DeepCopy()
+	if err := r.setDesiredStorageCluster(ctx, desired); err != nil {
 		return err
 	}
 
+	currentJSON, err := json.Marshal(&r.storageCluster.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to marshal current storage cluster spec: %w", err)
+	}
+	desiredJSON, err := json.Marshal(&desired.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to marshal desired storage cluster spec: %w", err)
+	}
+	diff, err := strategicpatch.CreateTwoWayMergePatch(currentJSON, desiredJSON, ocsv1.StorageClusterSpec{})
+	if err != nil {
+		return fmt.Errorf("unable to compute storage cluster spec diff: %w", err)
+	}
+	r.Log.Info("dry run, skipping StorageCluster update", "name", r.storageCluster.Name, "diff", string(diff))
+
+	// The annotation only records the diff of the primary storage cluster
+	if !r.isPrimaryStorageCluster() {
+		return nil
+	}
+	annotations := r.managedOCS.GetAnnotations()
+	if annotations[LastDryRunDiffAnnotation] == string(diff) {
+		return nil
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[LastDryRunDiffAnnotation] = string(diff)
+	r.managedOCS.SetAnnotations(annotations)
+
+	// The update response overwrites the status computed so far in this reconcile
+	status := r.managedOCS.Status.DeepCopy()
+	if err := r.update(ctx, r.managedOCS); err != nil {
+		return fmt.Errorf("failed to record the dry run diff on managedOCS: %w", err)
+	}
+	r.managedOCS.Status = *status
 	return nil
 }
 
-func (r *ManagedOCSReconciler) updateStorageClusterFromAddonParamsSecret(sc *ocsv1.StorageCluster) error {
+// reconcileKMSConnectionDetails maintains the ConfigMap through which OCS connects to the key
+// management service holding the OSD encryption keys
+func (r *ManagedOCSReconciler) reconcileKMSConnectionDetails(ctx context.Context) error {
+	encryption := r.managedOCS.Spec.EncryptionConfig
+	if encryption == nil || !encryption.Enabled || encryption.KMSEndpoint == "" || r.isDryRun() {
+		return nil
+	}
+	r.Log.Info("Reconciling KMS connection details")
+
+	kmsConfigMap := &corev1.ConfigMap{}
+	kmsConfigMap.Name = kmsConnectionDetailsConfigMapName
+	kmsConfigMap.Namespace = r.namespace
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, kmsConfigMap, func() error {
+		if err := r.own(kmsConfigMap); err != nil {
+			return err
+		}
+		if kmsConfigMap.Data == nil {
+			kmsConfigMap.Data = map[string]string{}
+		}
+		kmsConfigMap.Data["KMS_PROVIDER"] = kmsProvider
+		kmsConfigMap.Data["VAULT_ADDR"] = encryption.KMSEndpoint
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to reconcile KMS connection details: %w", err)
+	}
+	return nil
+}
+
+// applyNodeSelector replaces the node affinity of all the storage device sets with a required
+// affinity to the nodes matching every label of the selector. An empty selector keeps the
+// placement of the template.
+func applyNodeSelector(spec *ocsv1.StorageClusterSpec, nodeSelector map[string]string) {
+	if len(nodeSelector) == 0 {
+		return
+	}
+
+	// Sort the labels so the resulting spec does not change between reconciles
+	keys := make([]string, 0, len(nodeSelector))
+	for key := range nodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	requirements := make([]corev1.NodeSelectorRequirement, 0, len(keys))
+	for _, key := range keys {
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{nodeSelector[key]},
+		})
+	}
+
+	for i := range spec.StorageDeviceSets {
+		spec.StorageDeviceSets[i].Placement.NodeAffinity = &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: append([]corev1.NodeSelectorRequirement(nil), requirements...),
+				}},
+			},
+		}
+	}
+}
+
+// applyPodAntiAffinity replaces the pod anti-affinity of all the storage device sets with the
+// one of the affinity, or with the default one spreading the OSDs across zones when no affinity
+// is set
+func applyPodAntiAffinity(spec *ocsv1.StorageClusterSpec, affinity *corev1.Affinity) {
+	antiAffinity := utils.GetDefaultPodAntiAffinity(osdLabelKey, osdLabelValue)
+	if affinity != nil {
+		antiAffinity = affinity.PodAntiAffinity
+	}
+	for i := range spec.StorageDeviceSets {
+		spec.StorageDeviceSets[i].Placement.PodAntiAffinity = antiAffinity.DeepCopy()
+	}
+}
+
+// applyTolerations adds the tolerations to the placement of all the storage device sets,
+// keeping the tolerations of the template
+func applyTolerations(spec *ocsv1.StorageClusterSpec, tolerations []corev1.Toleration) {
+	for i := range spec.StorageDeviceSets {
+		placement := &spec.StorageDeviceSets[i].Placement
+		for j := range tolerations {
+			if !hasToleration(placement.Tolerations, &tolerations[j]) {
+				placement.Tolerations = append(placement.Tolerations, *tolerations[j].DeepCopy())
+			}
+		}
+	}
+}
+
+// applyResourceRequirements replaces the resource requirements of the components named in
+// requirements. The osd component maps to the resources of the storage device sets
+func applyResourceRequirements(spec *ocsv1.StorageClusterSpec, requirements map[string]corev1.ResourceRequirements) {
+	for component, resources := range requirements {
+		if component == osdResourcesKey {
+			for i := range spec.StorageDeviceSets {
+				spec.StorageDeviceSets[i].Resources = *resources.DeepCopy()
+			}
+			continue
+		}
+		if spec.Resources == nil {
+			spec.Resources = map[string]corev1.ResourceRequirements{}
+		}
+		spec.Resources[component] = *resources.DeepCopy()
+	}
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration *corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+// getStorageClusterName returns the name of the primary storage cluster managed by managedOCS
+func getStorageClusterName(managedOCS *v1.ManagedOCS) string {
+	return getManagedStorageClusters(managedOCS)[0].Name
+}
+
+// getManagedStorageClusters returns the storage clusters managed by managedOCS, the primary one
+// first. Without a list of storage clusters, the single storage cluster is named after
+// spec.storageClusterName, which defaults to ocs-storagecluster
+func getManagedStorageClusters(managedOCS *v1.ManagedOCS) []v1.ManagedStorageCluster {
+	if len(managedOCS.Spec.StorageClusters) == 0 {
+		name := managedOCS.Spec.StorageClusterName
+		if name == "" {
+			name = v1.DefaultStorageClusterName
+		}
+		return []v1.ManagedStorageCluster{{
+			Name:                   name,
+			StorageClusterTemplate: managedOCS.Spec.StorageClusterTemplate,
+		}}
+	}
+
+	storageClusters := make([]v1.ManagedStorageCluster, len(managedOCS.Spec.StorageClusters))
+	for i := range managedOCS.Spec.StorageClusters {
+		managedOCS.Spec.StorageClusters[i].DeepCopyInto(&storageClusters[i])
+		if storageClusters[i].StorageClusterTemplate == nil {
+			storageClusters[i].StorageClusterTemplate = managedOCS.Spec.StorageClusterTemplate
+		}
+	}
+	return storageClusters
+}
+
+// isOwnedByManagedOCS checks whether obj has an owner reference to a ManagedOCS resource
+func isOwnedByManagedOCS(obj metav1.Object) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+		if err == nil && gv.Group == v1.GroupVersion.Group && ownerRef.Kind == "ManagedOCS" {
+			return true
+		}
+	}
+	return false
+}
+
+// updateSpecDriftCondition compares the live storage cluster spec hash with the hash of the
+// last spec applied by the deployer
+func (r *ManagedOCSReconciler) updateSpecDriftCondition(specHash string) {
+	appliedSpecHash := r.managedOCS.Status.StorageClusterSpecHash
+	if appliedSpecHash == "" {
+		// Nothing was applied yet, so there is nothing to drift from
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionSpecDrift)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               v1.ConditionSpecDrift,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: r.managedOCS.Generation,
+		Reason:             "SpecInSync",
+		Message:            "The StorageCluster spec matches the last applied spec",
+	}
+	if specHash != appliedSpecHash {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "SpecModified"
+		condition.Message = "The StorageCluster spec was modified outside of the ManagedOCS workflow"
+	}
+	meta.SetStatusCondition(&r.managedOCS.Status.Conditions, condition)
+}
+
+// hashStorageClusterSpec returns the SHA-256 hex digest of the JSON encoding of spec
+func hashStorageClusterSpec(spec *ocsv1.StorageClusterSpec) (string, error) {
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("unable to hash storage cluster spec: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(specJSON)), nil
+}
+
+// getStorageClusterTemplate returns the storage cluster template from the ConfigMap referenced
+// for the loaded storage cluster, or the built-in template when there is no such reference.
+// The ConfigMap is only used with the strict reconcile strategy
+func (r *ManagedOCSReconciler) getStorageClusterTemplate(ctx context.Context) (*ocsv1.StorageCluster, error) {
+	desired := templates.StorageClusterTemplate.DeepCopy()
+
+	templateRef := r.storageClusterTemplateRef
+	if templateRef == nil || templateRef.Name == "" || r.reconcileStrategy != v1.ReconcileStrategyStrict {
+		return desired, nil
+	}
+
+	templateConfigMap := &corev1.ConfigMap{}
+	templateConfigMap.Name = templateRef.Name
+	templateConfigMap.Namespace = r.namespace
+	if err := r.get(ctx, templateConfigMap); err != nil {
+		err = fmt.Errorf("Failed to get storage cluster template ConfigMap %v: %w", templateRef.Name, err)
+		return nil, deployererrors.NewTemplateError(templateRef.Name, "TemplateNotFound", isRetriableError(err) || errors.IsNotFound(err), err)
+	}
+
+	data, ok := templateConfigMap.Data[storageClusterTemplateKey]
+	if !ok {
+		err := fmt.Errorf("Storage cluster template ConfigMap %v does not contain a %v entry", templateRef.Name, storageClusterTemplateKey)
+		return nil, deployererrors.NewTemplateError(templateRef.Name, "TemplateMissingEntry", false, err)
+	}
+	// The template can reference the ManagedOCS spec, e.g. {{ .Spec.StorageClusterName }}
+	data, err := utils.RenderTemplate(templateRef.Name, data, map[string]interface{}{
+		"Namespace": r.namespace,
+		"Spec":      r.managedOCS.Spec,
+	})
+	if err != nil {
+		err = fmt.Errorf("Invalid storage cluster template in ConfigMap %v: %w", templateRef.Name, err)
+		return nil, deployererrors.NewTemplateError(templateRef.Name, "TemplateInvalid", false, err)
+	}
+	jsonData, err := utilyaml.ToJSON([]byte(data))
+	if err != nil {
+		err = fmt.Errorf("Invalid storage cluster template in ConfigMap %v: %w", templateRef.Name, err)
+		return nil, deployererrors.NewTemplateError(templateRef.Name, "TemplateInvalid", false, err)
+	}
+	desired.Spec = ocsv1.StorageClusterSpec{}
+	if err := json.Unmarshal(jsonData, &desired.Spec); err != nil {
+		err = fmt.Errorf("Invalid storage cluster template in ConfigMap %v: %w", templateRef.Name, err)
+		return nil, deployererrors.NewTemplateError(templateRef.Name, "TemplateInvalid", false, err)
+	}
+	// A template without a version annotation on its ConfigMap is not versioned, and is
+	// applied without checking the version of the applied template
+	desired.Annotations = map[string]string{
+		templates.StorageClusterTemplateVersionAnnotation: templateConfigMap.Annotations[templates.StorageClusterTemplateVersionAnnotation],
+	}
+
+	return desired, nil
+}
+
+func (r *ManagedOCSReconciler) updateStorageClusterFromAddonParamsSecret(ctx context.Context, sc *ocsv1.StorageCluster) error {
 	// The addon param secret will contain the capacity of the cluster in Ti
 	// size = 1,  creates a cluster of 1 Ti capacity
 	// size = 2,  creates a cluster of 2 Ti capacity etc
@@ -560,7 +1522,7 @@ func (r *ManagedOCSReconciler) updateStorageClusterFromAddonParamsSecret(sc *ocs
 	addonParamSecret := &corev1.Secret{}
 	addonParamSecret.Name = r.AddonParamSecretName
 	addonParamSecret.Namespace = r.namespace
-	if err := r.get(addonParamSecret); err != nil {
+	if err := r.get(ctx, addonParamSecret); err != nil {
 		// Do not create the StorageCluster if the we fail to get the addon param secret
 		return fmt.Errorf("Failed to get the addon param secret, Secret Name: %v", r.AddonParamSecretName)
 	}
@@ -607,10 +1569,57 @@ func (r *ManagedOCSReconciler) updateStorageClusterFromAddonParamsSecret(sc *ocs
 	return nil
 }
 
-func (r *ManagedOCSReconciler) reconcilePrometheus() error {
+// mergeStorageClusterSpec merges the desired spec into the current spec. Fields are merged using
+// strategic merge patch semantics, while storage device sets are merged one by one, matched by name,
+// so that sub-fields like the device set count can be updated without replacing the whole set.
+func mergeStorageClusterSpec(current *ocsv1.StorageClusterSpec, desired *ocsv1.StorageClusterSpec) error {
+	deviceSets := make([]ocsv1.StorageDeviceSet, len(current.StorageDeviceSets))
+	copy(deviceSets, current.StorageDeviceSets)
+	for i := range desired.StorageDeviceSets {
+		desiredSet := &desired.StorageDeviceSets[i]
+		found := false
+		for j := range deviceSets {
+			if deviceSets[j].Name == desiredSet.Name {
+				if err := strategicMerge(&deviceSets[j], desiredSet); err != nil {
+					return fmt.Errorf("unable to merge storage device set %v: %w", desiredSet.Name, err)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			deviceSets = append(deviceSets, *desiredSet.DeepCopy())
+		}
+	}
+
+	if err := strategicMerge(current, desired); err != nil {
+		return fmt.Errorf("unable to merge storage cluster spec: %w", err)
+	}
+	current.StorageDeviceSets = deviceSets
+	return nil
+}
+
+// strategicMerge applies the JSON representation of patch on top of obj, in place
+func strategicMerge(obj interface{}, patch interface{}) error {
+	objJSON, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	merged, err := strategicpatch.StrategicMergePatch(objJSON, patchJSON, obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(merged, obj)
+}
+
+func (r *ManagedOCSReconciler) reconcilePrometheus(ctx context.Context) error {
 	r.Log.Info("Reconciling Prometheus")
 
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.prometheus, func() error {
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.prometheus, func() error {
 		if err := r.own(r.prometheus); err != nil {
 			return err
 		}
@@ -629,10 +1638,10 @@ func (r *ManagedOCSReconciler) reconcilePrometheus() error {
 	return nil
 }
 
-func (r *ManagedOCSReconciler) reconcileDMSPrometheusRule() error {
+func (r *ManagedOCSReconciler) reconcileDMSPrometheusRule(ctx context.Context) error {
 	r.Log.Info("Reconciling DMS Prometheus Rule")
 
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.dmsRule, func() error {
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.dmsRule, func() error {
 		if err := r.own(r.dmsRule); err != nil {
 			return err
 		}
@@ -649,12 +1658,12 @@ func (r *ManagedOCSReconciler) reconcileDMSPrometheusRule() error {
 	return nil
 }
 
-func (r *ManagedOCSReconciler) reconcileOCSInitialization() error {
+func (r *ManagedOCSReconciler) reconcileOCSInitialization(ctx context.Context) error {
 	r.Log.Info("Reconciling OCSInitialization")
 
 	ocsInitList := ocsv1.OCSInitializationList{}
-	if err := r.list(&ocsInitList); err != nil {
-		return fmt.Errorf("Could to list OCSInitialization resources: %v", err)
+	if err := r.list(ctx, &ocsInitList); err != nil {
+		return fmt.Errorf("Could to list OCSInitialization resources: %w", err)
 	}
 	if len(ocsInitList.Items) == 0 {
 		r.Log.V(-1).Info("OCSInitialization resource not found")
@@ -662,7 +1671,7 @@ func (r *ManagedOCSReconciler) reconcileOCSInitialization() error {
 		obj := &ocsInitList.Items[0]
 		if !obj.Spec.EnableCephTools {
 			obj.Spec.EnableCephTools = true
-			if err := r.update(obj); err != nil {
+			if err := r.update(ctx, obj); err != nil {
 				return err
 			}
 		}
@@ -670,9 +1679,9 @@ func (r *ManagedOCSReconciler) reconcileOCSInitialization() error {
 	return nil
 }
 
-func (r *ManagedOCSReconciler) reconcileAlertmanager() error {
+func (r *ManagedOCSReconciler) reconcileAlertmanager(ctx context.Context) error {
 	r.Log.Info("Reconciling Alertmanager")
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.alertmanager, func() error {
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.alertmanager, func() error {
 		if err := r.own(r.alertmanager); err != nil {
 			return err
 		}
@@ -694,16 +1703,16 @@ func (r *ManagedOCSReconciler) reconcileAlertmanager() error {
 	return nil
 }
 
-func (r *ManagedOCSReconciler) reconcileAlertmanagerConfig() error {
+func (r *ManagedOCSReconciler) reconcileAlertmanagerConfig(ctx context.Context) error {
 	r.Log.Info("Reconciling AlertmanagerConfig secret")
 
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.alertmanagerConfig, func() error {
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.alertmanagerConfig, func() error {
 		if err := r.own(r.alertmanagerConfig); err != nil {
 			return err
 		}
 
-		if err := r.get(r.pagerdutySecret); err != nil {
-			return fmt.Errorf("Unable to get pagerduty secret: %v", err)
+		if err := r.get(ctx, r.pagerdutySecret); err != nil {
+			return fmt.Errorf("Unable to get pagerduty secret: %w", err)
 		}
 		pagerdutySecretData := r.pagerdutySecret.Data
 		pagerdutyServiceKey := string(pagerdutySecretData["PAGERDUTY_KEY"])
@@ -711,8 +1720,8 @@ func (r *ManagedOCSReconciler) reconcileAlertmanagerConfig() error {
 			return fmt.Errorf("Pagerduty secret does not contain a PAGERDUTY_KEY entry")
 		}
 
-		if err := r.get(r.deadMansSnitchSecret); err != nil {
-			return fmt.Errorf("Unable to get DeadMan's Snitch secret: %v", err)
+		if err := r.get(ctx, r.deadMansSnitchSecret); err != nil {
+			return fmt.Errorf("Unable to get DeadMan's Snitch secret: %w", err)
 		}
 		dmsURL := string(r.deadMansSnitchSecret.Data["SNITCH_URL"])
 		if dmsURL == "" {
@@ -741,10 +1750,10 @@ func (r *ManagedOCSReconciler) reconcileAlertmanagerConfig() error {
 	return err
 }
 
-func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitorAuthSecret() error {
+func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitorAuthSecret(ctx context.Context) error {
 	r.Log.Info("Reconciling k8sMetricsServiceMonitorAuthSecret")
 
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.k8sMetricsServiceMonitorAuthSecret, func() error {
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.k8sMetricsServiceMonitorAuthSecret, func() error {
 		if err := r.own(r.k8sMetricsServiceMonitorAuthSecret); err != nil {
 			return err
 		}
@@ -752,8 +1761,8 @@ func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitorAuthSecret() err
 		secret := &corev1.Secret{}
 		secret.Name = grafanaDatasourceSecretName
 		secret.Namespace = openshiftMonitoringNamespace
-		if err := r.unrestrictedGet(secret); err != nil {
-			return fmt.Errorf("Failed to get grafana-datasources secret from openshift-monitoring namespace: %v", err)
+		if err := r.unrestrictedGet(ctx, secret); err != nil {
+			return fmt.Errorf("Failed to get grafana-datasources secret from openshift-monitoring namespace: %w", err)
 		}
 
 		authInfoStructure := struct {
@@ -764,7 +1773,7 @@ func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitorAuthSecret() err
 		}{}
 
 		if err := json.Unmarshal(secret.Data[grafanaDatasourceSecretKey], &authInfoStructure); err != nil {
-			return fmt.Errorf("Could not unmarshal Grapana datasource data: %v", err)
+			return fmt.Errorf("Could not unmarshal Grapana datasource data: %w", err)
 		}
 
 		r.k8sMetricsServiceMonitorAuthSecret.Data = nil
@@ -783,15 +1792,15 @@ func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitorAuthSecret() err
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("Failed to update k8sMetricsServiceMonitorAuthSecret: %v", err)
+		return fmt.Errorf("Failed to update k8sMetricsServiceMonitorAuthSecret: %w", err)
 	}
 	return nil
 }
 
-func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitor() error {
+func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitor(ctx context.Context) error {
 	r.Log.Info("Reconciling k8sMetricsServiceMonitor")
 
-	_, err := ctrl.CreateOrUpdate(r.ctx, r.Client, r.k8sMetricsServiceMonitor, func() error {
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.k8sMetricsServiceMonitor, func() error {
 		if err := r.own(r.k8sMetricsServiceMonitor); err != nil {
 			return err
 		}
@@ -800,7 +1809,7 @@ func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitor() error {
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("Failed to update k8sMetricsServiceMonitor: %v", err)
+		return fmt.Errorf("Failed to update k8sMetricsServiceMonitor: %w", err)
 	}
 	return nil
 }
@@ -809,41 +1818,41 @@ func (r *ManagedOCSReconciler) reconcileK8SMetricsServiceMonitor() error {
 // found in the target namespace with a label that matches the label selector the defined on the Prometheus resource
 // we are reconciling in reconcilePrometheus. Doing so instructs the Prometheus instance to notice and react to these labeled
 // monitoring resources
-func (r *ManagedOCSReconciler) reconcileMonitoringResources() error {
+func (r *ManagedOCSReconciler) reconcileMonitoringResources(ctx context.Context) error {
 	r.Log.Info("reconciling monitoring resources")
 
 	podMonitorList := promv1.PodMonitorList{}
-	if err := r.list(&podMonitorList); err != nil {
-		return fmt.Errorf("Could not list pod monitors: %v", err)
+	if err := r.list(ctx, &podMonitorList); err != nil {
+		return fmt.Errorf("Could not list pod monitors: %w", err)
 	}
 	for i := range podMonitorList.Items {
 		obj := podMonitorList.Items[i]
 		utils.AddLabel(obj, monLabelKey, monLabelValue)
-		if err := r.update(obj); err != nil {
+		if err := r.update(ctx, obj); err != nil {
 			return err
 		}
 	}
 
 	serviceMonitorList := promv1.ServiceMonitorList{}
-	if err := r.list(&serviceMonitorList); err != nil {
-		return fmt.Errorf("Could not list service monitors: %v", err)
+	if err := r.list(ctx, &serviceMonitorList); err != nil {
+		return fmt.Errorf("Could not list service monitors: %w", err)
 	}
 	for i := range serviceMonitorList.Items {
 		obj := serviceMonitorList.Items[i]
 		utils.AddLabel(obj, monLabelKey, monLabelValue)
-		if err := r.update(obj); err != nil {
+		if err := r.update(ctx, obj); err != nil {
 			return err
 		}
 	}
 
 	promRuleList := promv1.PrometheusRuleList{}
-	if err := r.list(&promRuleList); err != nil {
-		return fmt.Errorf("Could not list prometheus rules: %v", err)
+	if err := r.list(ctx, &promRuleList); err != nil {
+		return fmt.Errorf("Could not list prometheus rules: %w", err)
 	}
 	for i := range promRuleList.Items {
 		obj := promRuleList.Items[i]
 		utils.AddLabel(obj, monLabelKey, monLabelValue)
-		if err := r.update(obj); err != nil {
+		if err := r.update(ctx, obj); err != nil {
 			return err
 		}
 	}
@@ -852,14 +1861,14 @@ func (r *ManagedOCSReconciler) reconcileMonitoringResources() error {
 }
 
 // reconcileRookCephOperatorConfig is used to set resource request and limits on csi containers
-func (r *ManagedOCSReconciler) reconcileRookCephOperatorConfig() error {
+func (r *ManagedOCSReconciler) reconcileRookCephOperatorConfig(ctx context.Context) error {
 	rookConfigMap := &corev1.ConfigMap{}
 	rookConfigMap.Name = rookConfigMapName
 	rookConfigMap.Namespace = r.namespace
 
-	if err := r.get(rookConfigMap); err != nil {
+	if err := r.get(ctx, rookConfigMap); err != nil {
 		// Because resource limits will not be set, failure to get the Rook ConfigMap results in failure to reconcile.
-		return fmt.Errorf("Failed to get Rook ConfigMap: %v", err)
+		return fmt.Errorf("Failed to get Rook ConfigMap: %w", err)
 	}
 
 	if rookConfigMap.Data == nil {
@@ -956,8 +1965,8 @@ func (r *ManagedOCSReconciler) reconcileRookCephOperatorConfig() error {
 		rookConfigMap.Data["CSI_CEPHFS_PROVISIONER_RESOURCE"] = fsProvisionerRequirements
 		rookConfigMap.Data["CSI_CEPHFS_PLUGIN_RESOURCE"] = fsPluginRequirements
 
-		if err := r.update(rookConfigMap); err != nil {
-			return fmt.Errorf("Failed to update Rook ConfigMap: %v", err)
+		if err := r.update(ctx, rookConfigMap); err != nil {
+			return fmt.Errorf("Failed to update Rook ConfigMap: %w", err)
 		}
 
 	}
@@ -965,12 +1974,12 @@ func (r *ManagedOCSReconciler) reconcileRookCephOperatorConfig() error {
 	return nil
 }
 
-func (r *ManagedOCSReconciler) checkUninstallCondition() bool {
+func (r *ManagedOCSReconciler) checkUninstallCondition(ctx context.Context) bool {
 	configmap := &corev1.ConfigMap{}
 	configmap.Name = r.AddonConfigMapName
 	configmap.Namespace = r.namespace
 
-	err := r.get(configmap)
+	err := r.get(ctx, configmap)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			r.Log.Error(err, "Unable to get addon delete configmap")
@@ -982,17 +1991,38 @@ func (r *ManagedOCSReconciler) checkUninstallCondition() bool {
 }
 
 func (r *ManagedOCSReconciler) areComponentsReadyForUninstall() bool {
-	subComponents := r.managedOCS.Status.Components
+	return areComponentsReady(&r.managedOCS.Status)
+}
+
+func areComponentsReady(status *v1.ManagedOCSStatus) bool {
+	subComponents := status.Components
 	return subComponents.StorageCluster.State == v1.ComponentReady &&
 		subComponents.Prometheus.State == v1.ComponentReady &&
 		subComponents.Alertmanager.State == v1.ComponentReady
 }
 
-func (r *ManagedOCSReconciler) findOCSVolumeClaims() (bool, error) {
+// isReconcilePaused reports whether the ManagedOCS resource carries the reconcile pause annotation
+func (r *ManagedOCSReconciler) isReconcilePaused() bool {
+	return r.managedOCS.GetAnnotations()[ReconcilePauseAnnotation] == "true"
+}
+
+func (r *ManagedOCSReconciler) isDryRun() bool {
+	return r.managedOCS.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+// recordEvent records an event on the ManagedOCS resource, if the resource exists
+func (r *ManagedOCSReconciler) recordEvent(eventType, reason, messageFmt string, args ...interface{}) {
+	if r.recorder == nil || r.managedOCS.UID == "" {
+		return
+	}
+	r.recorder.Eventf(r.managedOCS, eventType, reason, messageFmt, args...)
+}
+
+func (r *ManagedOCSReconciler) findOCSVolumeClaims(ctx context.Context) (bool, error) {
 	pvcList := &corev1.PersistentVolumeClaimList{}
-	err := r.UnrestrictedClient.List(r.ctx, pvcList)
+	err := r.UnrestrictedClient.List(ctx, pvcList)
 	if err != nil {
-		return false, fmt.Errorf("unable to list pvcs: %v", err)
+		return false, fmt.Errorf("unable to list pvcs: %w", err)
 	}
 	for i := range pvcList.Items {
 		scName := *pvcList.Items[i].Spec.StorageClassName
@@ -1003,10 +2033,18 @@ func (r *ManagedOCSReconciler) findOCSVolumeClaims() (bool, error) {
 	return false, nil
 }
 
-func (r *ManagedOCSReconciler) reconcileOCSCSV() error {
+func (r *ManagedOCSReconciler) findOSDPods(ctx context.Context) (bool, error) {
+	podList := &corev1.PodList{}
+	if err := r.list(ctx, podList, client.MatchingLabels{osdLabelKey: osdLabelValue}); err != nil {
+		return false, fmt.Errorf("unable to list osd pods: %w", err)
+	}
+	return len(podList.Items) > 0, nil
+}
+
+func (r *ManagedOCSReconciler) reconcileOCSCSV(ctx context.Context) error {
 	csvList := opv1a1.ClusterServiceVersionList{}
-	if err := r.list(&csvList); err != nil {
-		return fmt.Errorf("unable to list csv resources: %v", err)
+	if err := r.list(ctx, &csvList); err != nil {
+		return fmt.Errorf("unable to list csv resources: %w", err)
 	}
 
 	csv := getCSVByPrefix(csvList, ocsOperatorName)
@@ -1025,12 +2063,18 @@ func (r *ManagedOCSReconciler) reconcileOCSCSV() error {
 					container.Resources = resources
 					isChanged = true
 				}
+				if applyOCSOperatorImageOverrides(container, r.managedOCS.Spec.ImageOverrides) {
+					isChanged = true
+				}
 			case "rook-ceph-operator":
 				resources := utils.GetResourceRequirements("rook-ceph-operator")
 				if !equality.Semantic.DeepEqual(container.Resources, resources) {
 					container.Resources = resources
 					isChanged = true
 				}
+				if applyRookOperatorImageOverride(container, r.managedOCS.Spec.ImageOverrides) {
+					isChanged = true
+				}
 			case "ocs-metrics-exporter":
 				resources := utils.GetResourceRequirements("ocs-metrics-exporter")
 				if !equality.Semantic.DeepEqual(container.Resources, resources) {
@@ -1043,34 +2087,34 @@ func (r *ManagedOCSReconciler) reconcileOCSCSV() error {
 		}
 	}
 	if isChanged {
-		if err := r.update(csv); err != nil {
-			return fmt.Errorf("Failed to update OCS CSV with resource requirements: %v", err)
+		if err := r.update(ctx, csv); err != nil {
+			return fmt.Errorf("Failed to update OCS CSV with resource requirements and image overrides: %w", err)
 		}
 	}
 	return nil
 }
 
-func (r *ManagedOCSReconciler) removeOLMComponents() error {
+func (r *ManagedOCSReconciler) removeOLMComponents(ctx context.Context) error {
 
 	r.Log.Info("Deleting subscription")
 	subscription := &opv1a1.Subscription{}
 	subscription.Namespace = r.namespace
 	subscription.Name = r.DeployerSubscriptionName
-	if err := r.delete(subscription); err != nil {
-		return fmt.Errorf("unable to delete the deployer subscription: %v", err)
+	if err := r.delete(ctx, subscription); err != nil {
+		return fmt.Errorf("unable to delete the deployer subscription: %w", err)
 	}
 	r.Log.Info("deployer subscription removed successfully")
 
 	r.Log.Info("deleting deployer csv")
 	csvList := opv1a1.ClusterServiceVersionList{}
-	if err := r.list(&csvList); err != nil {
-		return fmt.Errorf("unable to list csv resources: %v", err)
+	if err := r.list(ctx, &csvList); err != nil {
+		return fmt.Errorf("unable to list csv resources: %w", err)
 	}
 
 	csv := getCSVByPrefix(csvList, deployerCSVPrefix)
 	if csv != nil {
-		if err := r.delete(csv); err != nil {
-			return fmt.Errorf("Unable to delete csv: %v", err)
+		if err := r.delete(ctx, csv); err != nil {
+			return fmt.Errorf("Unable to delete csv: %w", err)
 		}
 	}
 
@@ -1078,25 +2122,25 @@ func (r *ManagedOCSReconciler) removeOLMComponents() error {
 	return nil
 }
 
-func (r *ManagedOCSReconciler) get(obj runtime.Object) error {
+func (r *ManagedOCSReconciler) get(ctx context.Context, obj runtime.Object) error {
 	key, err := client.ObjectKeyFromObject(obj)
 	if err != nil {
 		return err
 	}
-	return r.Client.Get(r.ctx, key, obj)
+	return r.Client.Get(ctx, key, obj)
 }
 
-func (r *ManagedOCSReconciler) list(obj runtime.Object) error {
-	listOptions := client.InNamespace(r.namespace)
-	return r.Client.List(r.ctx, obj, listOptions)
+func (r *ManagedOCSReconciler) list(ctx context.Context, obj runtime.Object, opts ...client.ListOption) error {
+	listOptions := append([]client.ListOption{client.InNamespace(r.namespace)}, opts...)
+	return r.Client.List(ctx, obj, listOptions...)
 }
 
-func (r *ManagedOCSReconciler) update(obj runtime.Object) error {
-	return r.Client.Update(r.ctx, obj)
+func (r *ManagedOCSReconciler) update(ctx context.Context, obj runtime.Object) error {
+	return r.Client.Update(ctx, obj)
 }
 
-func (r *ManagedOCSReconciler) delete(obj runtime.Object) error {
-	if err := r.Client.Delete(r.ctx, obj); err != nil && !errors.IsNotFound(err) {
+func (r *ManagedOCSReconciler) delete(ctx context.Context, obj runtime.Object) error {
+	if err := r.Client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 	return nil
@@ -1122,10 +2166,10 @@ func getCSVByPrefix(csvList opv1a1.ClusterServiceVersionList, name string) *opv1
 	return csv
 }
 
-func (r *ManagedOCSReconciler) unrestrictedGet(obj runtime.Object) error {
+func (r *ManagedOCSReconciler) unrestrictedGet(ctx context.Context, obj runtime.Object) error {
 	key, err := client.ObjectKeyFromObject(obj)
 	if err != nil {
 		return err
 	}
-	return r.UnrestrictedClient.Get(r.ctx, key, obj)
+	return r.UnrestrictedClient.Get(ctx, key, obj)
 }
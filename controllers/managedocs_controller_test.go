@@ -3,23 +3,32 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
 	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/templates"
 	utils "github.com/openshift/ocs-osd-deployer/testutils"
 	ctrlutils "github.com/openshift/ocs-osd-deployer/utils"
+	"github.com/openshift/ocs-osd-deployer/utils/sizing"
 	opv1a1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	promv1a1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	rook "github.com/rook/rook/pkg/apis/rook.io/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("ManagedOCS controller", func() {
@@ -38,7 +47,7 @@ var _ = Describe("ManagedOCS controller", func() {
 	}
 	scTemplate := ocsv1.StorageCluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      storageClusterName,
+			Name:      v1.DefaultStorageClusterName,
 			Namespace: testPrimaryNamespace,
 		},
 	}
@@ -449,6 +458,13 @@ var _ = Describe("ManagedOCS controller", func() {
 
 				By("Creating an alertmanager resource")
 				utils.WaitForResource(k8sClient, ctx, amTemplate.DeepCopy(), timeout, interval)
+
+				By("Recording the storagecluster in the ManagedOCS resource status")
+				Eventually(func() *corev1.LocalObjectReference {
+					managedOCS := managedOCSTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.Status.StorageClusterRef
+				}, timeout, interval).Should(Equal(&corev1.LocalObjectReference{Name: v1.DefaultStorageClusterName}))
 			})
 		})
 		When("there is no rook-ceph-operator-config ConfigMap", func() {
@@ -570,6 +586,100 @@ var _ = Describe("ManagedOCS controller", func() {
 					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
 					return managedOCS.Status.Components.StorageCluster.State
 				}, timeout, interval).Should(Equal(v1.ComponentReady))
+
+				By("by setting Status.Phase to Ready")
+				Eventually(func() v1.ManagedOCSPhase {
+					managedOCS := managedOCSTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.Status.Phase
+				}, timeout, interval).Should(Equal(v1.PhaseReady))
+			})
+			It("should create the OSD pod disruption budget", func() {
+				pdb := &policyv1beta1.PodDisruptionBudget{}
+				pdb.Name = osdPDBName
+				pdb.Namespace = testPrimaryNamespace
+				Eventually(func() error {
+					return k8sClient.Get(ctx, utils.GetResourceKey(pdb), pdb)
+				}, timeout, interval).Should(Succeed())
+				Expect(pdb.Spec).Should(Equal(getOSDPDBSpec()))
+			})
+			It("should set the last sync time in the ManagedOCS resource status", func() {
+				Eventually(func() bool {
+					managedOCS := managedOCSTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.Status.LastSyncTime.IsZero()
+				}, timeout, interval).Should(BeFalse())
+			})
+			It("should record the applied template version in the ManagedOCS resource status", func() {
+				Eventually(func() string {
+					managedOCS := managedOCSTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.Status.AppliedTemplateVersion
+				}, timeout, interval).Should(Equal(templates.StorageClusterTemplateVersion))
+			})
+		})
+		When("the storagecluster reports status conditions", func() {
+			It("should mirror them in the ManagedOCS resource status", func() {
+				sc := scTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+				sc.Status.Conditions = []conditionsv1.Condition{{
+					Type:               conditionsv1.ConditionAvailable,
+					Status:             corev1.ConditionTrue,
+					Reason:             "ReconcileCompleted",
+					Message:            "Reconcile completed successfully",
+					LastHeartbeatTime:  metav1.Now(),
+					LastTransitionTime: metav1.Now(),
+				}}
+				Expect(k8sClient.Status().Update(ctx, sc)).Should(Succeed())
+
+				By("by setting the Available condition on the ManagedOCS resource")
+				managedOCS := managedOCSTemplate.DeepCopy()
+				key := utils.GetResourceKey(managedOCS)
+				Eventually(func() bool {
+					Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+					return meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionStorageClusterAvailable)
+				}, timeout, interval).Should(BeTrue())
+
+				By("by removing the condition once the storagecluster no longer reports it")
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+				sc.Status.Conditions = nil
+				Expect(k8sClient.Status().Update(ctx, sc)).Should(Succeed())
+				Eventually(func() *metav1.Condition {
+					Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+					return meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionStorageClusterAvailable)
+				}, timeout, interval).Should(BeNil())
+			})
+		})
+		When("the storagecluster reports a degraded condition", func() {
+			It("should set the OSDDegraded condition on the ManagedOCS resource", func() {
+				sc := scTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+				sc.Status.Conditions = []conditionsv1.Condition{{
+					Type:               conditionsv1.ConditionDegraded,
+					Status:             corev1.ConditionTrue,
+					Reason:             "OSDDown",
+					LastHeartbeatTime:  metav1.Now(),
+					LastTransitionTime: metav1.Now(),
+				}}
+				Expect(k8sClient.Status().Update(ctx, sc)).Should(Succeed())
+
+				managedOCS := managedOCSTemplate.DeepCopy()
+				key := utils.GetResourceKey(managedOCS)
+				Eventually(func() bool {
+					Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+					return meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionOSDDegraded)
+				}, timeout, interval).Should(BeTrue())
+				condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionOSDDegraded)
+				Expect(condition.Message).To(ContainSubstring("0 OSDs are not ready"))
+
+				By("by clearing the condition once the storagecluster recovers")
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+				sc.Status.Conditions = nil
+				Expect(k8sClient.Status().Update(ctx, sc)).Should(Succeed())
+				Eventually(func() bool {
+					Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+					return meta.IsStatusConditionFalse(managedOCS.Status.Conditions, v1.ConditionOSDDegraded)
+				}, timeout, interval).Should(BeTrue())
 			})
 		})
 		When("prometheus has non-ready replicas", func() {
@@ -719,6 +829,76 @@ var _ = Describe("ManagedOCS controller", func() {
 				}, timeout, interval).Should(Equal(spec))
 			})
 		})
+		When("the storagecluster resource is modified while the reconcile is paused", func() {
+			It("should only revert the changes once the reconcile is resumed", func() {
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.SetAnnotations(map[string]string{ReconcilePauseAnnotation: "true"})
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				sc := scTemplate.DeepCopy()
+				scKey := utils.GetResourceKey(sc)
+				Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+				spec := sc.Spec.DeepCopy()
+				sc.Spec = ocsv1.StorageClusterSpec{}
+				Expect(k8sClient.Update(ctx, sc)).Should(Succeed())
+
+				By("by leaving the storagecluster untouched while paused")
+				Consistently(func() *ocsv1.StorageClusterSpec {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return &sc.Spec
+				}, timeout, interval).Should(Equal(&sc.Spec))
+
+				By("by reverting the changes once the pause annotation is removed")
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.SetAnnotations(nil)
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+				Eventually(func() *ocsv1.StorageClusterSpec {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return &sc.Spec
+				}, timeout, interval).Should(Equal(spec))
+			})
+		})
+		When("the storagecluster resource is modified while the dry run annotation is set", func() {
+			It("should record the changes it would make without reverting them", func() {
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.SetAnnotations(map[string]string{DryRunAnnotation: "true"})
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				sc := scTemplate.DeepCopy()
+				scKey := utils.GetResourceKey(sc)
+				Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+				spec := sc.Spec.DeepCopy()
+				sc.Spec.Version = "dry-run-version"
+				Expect(k8sClient.Update(ctx, sc)).Should(Succeed())
+
+				By("by recording the diff in the ManagedOCS annotations")
+				Eventually(func() string {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.GetAnnotations()[LastDryRunDiffAnnotation]
+				}, timeout, interval).Should(ContainSubstring(`"version"`))
+
+				By("by leaving the storagecluster untouched")
+				Consistently(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return sc.Spec.Version
+				}, timeout, interval).Should(Equal("dry-run-version"))
+
+				By("by reverting the changes once the dry run annotation is removed")
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.SetAnnotations(nil)
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+				Eventually(func() *ocsv1.StorageClusterSpec {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return &sc.Spec
+				}, timeout, interval).Should(Equal(spec))
+			})
+		})
 		When("the storagecluster resource is modified while the reconcile strategy is set to none", func() {
 			It("should not revert any changes back to the managed state", func() {
 				// Set managed OCS to reconcile strategy to none
@@ -727,6 +907,13 @@ var _ = Describe("ManagedOCS controller", func() {
 				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
 				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
 
+				// Wait for the new generation to be reconciled
+				generation := managedOCS.Generation
+				Eventually(func() int64 {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.Status.ObservedGeneration
+				}, timeout, interval).Should(Equal(generation))
+
 				// Get an updated storagecluster
 				sc := scTemplate.DeepCopy()
 				scKey := utils.GetResourceKey(sc)
@@ -744,6 +931,415 @@ var _ = Describe("ManagedOCS controller", func() {
 				}, timeout, interval).Should(Equal(&sc.Spec))
 			})
 		})
+		When("the storagecluster resource drifts from the applied spec while the reconcile strategy is set to none", func() {
+			It("should surface a SpecDrift condition in the ManagedOCS resource status", func() {
+				// Let the deployer apply the managed state
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				sc := scTemplate.DeepCopy()
+				scKey := utils.GetResourceKey(sc)
+				Eventually(func() bool {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					specHash, err := hashStorageClusterSpec(&sc.Spec)
+					Expect(err).ToNot(HaveOccurred())
+					return managedOCS.Status.StorageClusterSpecHash == specHash
+				}, timeout, interval).Should(BeTrue())
+
+				// Stop enforcing the spec and modify the storagecluster
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+				sc.Spec.Version = "drifted-version"
+				Expect(k8sClient.Update(ctx, sc)).Should(Succeed())
+
+				Eventually(func() metav1.ConditionStatus {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionSpecDrift)
+					if condition == nil {
+						return metav1.ConditionUnknown
+					}
+					return condition.Status
+				}, timeout, interval).Should(Equal(metav1.ConditionTrue))
+			})
+		})
+		When("the storagecluster resource is modified while the reconcile strategy is set to force", func() {
+			It("should merge the managed state into the resource without reverting unmanaged fields", func() {
+				// Set managed OCS to reconcile strategy to force
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyForce
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				// Get an updated storagecluster
+				sc := scTemplate.DeepCopy()
+				scKey := utils.GetResourceKey(sc)
+				Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+
+				// Update to a spec that only has a field which is not managed by the template
+				sc.Spec = ocsv1.StorageClusterSpec{
+					Version: "test-version",
+				}
+				Expect(k8sClient.Update(ctx, sc)).Should(Succeed())
+
+				// Wait for the managed fields to be merged back
+				Eventually(func() bool {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return len(sc.Spec.StorageDeviceSets) > 0 && sc.Spec.Resources != nil
+				}, timeout, interval).Should(BeTrue())
+
+				// Verify that the unmanaged field was not reverted
+				Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+				Expect(sc.Spec.Version).Should(Equal("test-version"))
+			})
+		})
+		When("the storagecluster resource is not owned by the ManagedOCS resource", func() {
+			It("should only adopt it when adoptExistingCluster is set", func() {
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				// Wait for the managed state to be applied
+				sc := scTemplate.DeepCopy()
+				scKey := utils.GetResourceKey(sc)
+				Eventually(func() string {
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return sc.Spec.Version
+				}, timeout, interval).Should(BeEmpty())
+
+				// Orphan the storagecluster and modify its spec
+				spec := sc.Spec.DeepCopy()
+				sc.OwnerReferences = nil
+				sc.Spec.Version = "unmanaged-version"
+				Expect(k8sClient.Update(ctx, sc)).Should(Succeed())
+
+				// Verify that the storagecluster is left untouched
+				Consistently(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return sc.Spec.Version
+				}, timeout, interval).Should(Equal("unmanaged-version"))
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				Expect(meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionAdoptionRefused)).Should(BeTrue())
+
+				// Allow adoption and wait for the storagecluster to be owned, with its spec preserved
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.AdoptExistingCluster = true
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+				Eventually(func() bool {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return isOwnedByManagedOCS(sc) && sc.Spec.Version == "unmanaged-version"
+				}, timeout, interval).Should(BeTrue())
+				Eventually(func() v1.ReconcileStrategy {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return managedOCS.Spec.ReconcileStrategy
+				}, timeout, interval).Should(Equal(v1.ReconcileStrategyNone))
+
+				// Switching back to the strict reconcile strategy applies the managed state again
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.AdoptExistingCluster = false
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+				Eventually(func() bool {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return equality.Semantic.DeepEqual(&sc.Spec, spec)
+				}, timeout, interval).Should(BeTrue())
+			})
+		})
+		When("the ManagedOCS lists several storage clusters", func() {
+			It("should create and own each of them", func() {
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.StorageClusters = []v1.ManagedStorageCluster{
+					{Name: v1.DefaultStorageClusterName},
+					{Name: "test-data-storagecluster"},
+				}
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				dataSC := &ocsv1.StorageCluster{}
+				dataSC.Name = "test-data-storagecluster"
+				dataSC.Namespace = testPrimaryNamespace
+				Eventually(func() bool {
+					if err := k8sClient.Get(ctx, utils.GetResourceKey(dataSC), dataSC); err != nil {
+						return false
+					}
+					return isOwnedByManagedOCS(dataSC)
+				}, timeout, interval).Should(BeTrue())
+
+				// The primary storage cluster is the only one reported in the status
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				Expect(managedOCS.Status.StorageClusterRef).Should(Equal(&corev1.LocalObjectReference{Name: v1.DefaultStorageClusterName}))
+
+				managedOCS.Spec.StorageClusters = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+				Expect(k8sClient.Delete(ctx, dataSC)).Should(Succeed())
+			})
+		})
+		When("the ManagedOCS references a storage cluster template ConfigMap", func() {
+			It("should use the template from the ConfigMap as the managed state", func() {
+				// Create a template with a custom device set count and a custom version
+				templateConfigMap := &corev1.ConfigMap{}
+				templateConfigMap.Name = "test-storagecluster-template"
+				templateConfigMap.Namespace = testPrimaryNamespace
+				templateConfigMap.Labels = map[string]string{StorageClusterTemplateLabel: "true"}
+				templateConfigMap.Data = map[string]string{
+					storageClusterTemplateKey: "version: template-version\n" +
+						"storageDeviceSets:\n" +
+						"- name: default\n" +
+						"  count: 1\n" +
+						"  replica: 3\n",
+				}
+				Expect(k8sClient.Create(ctx, templateConfigMap)).Should(Succeed())
+
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.StorageClusterTemplate = &corev1.LocalObjectReference{Name: templateConfigMap.Name}
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				// Wait for the storagecluster to reflect the template from the ConfigMap
+				scKey := utils.GetResourceKey(scTemplate.DeepCopy())
+				Eventually(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return sc.Spec.Version
+				}, timeout, interval).Should(Equal("template-version"))
+
+				// Changes to the template are watched
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(templateConfigMap), templateConfigMap)).Should(Succeed())
+				templateConfigMap.Data[storageClusterTemplateKey] = strings.Replace(
+					templateConfigMap.Data[storageClusterTemplateKey], "template-version", "updated-template-version", 1)
+				Expect(k8sClient.Update(ctx, templateConfigMap)).Should(Succeed())
+				Eventually(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return sc.Spec.Version
+				}, timeout, interval).Should(Equal("updated-template-version"))
+
+				// Remove the reference and verify the built-in template is restored
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.StorageClusterTemplate = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+				Eventually(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, scKey, sc)).Should(Succeed())
+					return sc.Spec.Version
+				}, timeout, interval).Should(BeEmpty())
+
+				Expect(k8sClient.Delete(ctx, templateConfigMap)).Should(Succeed())
+			})
+		})
+		When("the storage device set count is set in the ManagedOCS spec", func() {
+			setStorageNodeDevices := func(devices string) {
+				for i := 0; i < 3; i++ {
+					node := &corev1.Node{}
+					node.Name = fmt.Sprintf("%s-%d", testStorageNodeNamePrefix, i)
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(node), node)).Should(Succeed())
+					if devices == "" {
+						delete(node.Labels, sizing.StorageNodeLabelKey)
+						delete(node.Annotations, sizing.DeviceCountAnnotationPrefix+autoSizingDeviceClass)
+					} else {
+						ctrlutils.AddLabel(node, sizing.StorageNodeLabelKey, "")
+						node.SetAnnotations(map[string]string{sizing.DeviceCountAnnotationPrefix + autoSizingDeviceClass: devices})
+					}
+					Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+				}
+			}
+			It("should override the count of all storage device sets", func() {
+				// The storage nodes must be able to host the scaled up storage device sets
+				setStorageNodeDevices("5")
+				defer setStorageNodeDevices("")
+
+				count := int32(5)
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.StorageDeviceSetCount = &count
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() bool {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+					if len(sc.Spec.StorageDeviceSets) == 0 {
+						return false
+					}
+					for _, ds := range sc.Spec.StorageDeviceSets {
+						if ds.Count != int(count) {
+							return false
+						}
+					}
+					return true
+				}, timeout, interval).Should(BeTrue())
+
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.StorageDeviceSetCount = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+			})
+		})
+		When("encryption is enabled in the ManagedOCS spec", func() {
+			It("should enable the storagecluster encryption and record the KMS connection details", func() {
+				endpoint := "https://vault.example.com:8200"
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.EncryptionConfig = &v1.EncryptionConfig{Enabled: true, KMSEndpoint: endpoint}
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() bool {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+					return sc.Spec.Encryption.Enable
+				}, timeout, interval).Should(BeTrue())
+
+				kmsConfigMap := &corev1.ConfigMap{}
+				kmsConfigMap.Name = kmsConnectionDetailsConfigMapName
+				kmsConfigMap.Namespace = testPrimaryNamespace
+				Eventually(func() map[string]string {
+					if err := k8sClient.Get(ctx, utils.GetResourceKey(kmsConfigMap), kmsConfigMap); err != nil {
+						return nil
+					}
+					return kmsConfigMap.Data
+				}, timeout, interval).Should(Equal(map[string]string{
+					"KMS_PROVIDER": kmsProvider,
+					"VAULT_ADDR":   endpoint,
+				}))
+
+				// The validating webhook is not served by the test environment
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.EncryptionConfig = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+			})
+		})
+		When("managed resources are set in the ManagedOCS spec", func() {
+			It("should merge them on top of the storagecluster managed resources", func() {
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.ManagedResources = &ocsv1.ManagedResourcesSpec{
+					CephObjectStores: ocsv1.ManageCephObjectStores{ReconcileStrategy: "ignore"},
+				}
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+					return sc.Spec.ManagedResources.CephObjectStores.ReconcileStrategy
+				}, timeout, interval).Should(Equal("ignore"))
+
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ManagedResources = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() string {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+					return sc.Spec.ManagedResources.CephObjectStores.ReconcileStrategy
+				}, timeout, interval).ShouldNot(Equal("ignore"))
+			})
+		})
+		When("a network spec is set in the ManagedOCS spec", func() {
+			It("should set it in the storagecluster spec", func() {
+				network := &rook.NetworkSpec{
+					Provider:  "multus",
+					Selectors: map[string]string{"public": "openshift-storage/public-net"},
+				}
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				managedOCS.Spec.NetworkSpec = network
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() *rook.NetworkSpec {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+					return sc.Spec.Network
+				}, timeout, interval).Should(Equal(network))
+
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.NetworkSpec = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() *rook.NetworkSpec {
+					sc := scTemplate.DeepCopy()
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(sc), sc)).Should(Succeed())
+					return sc.Spec.Network
+				}, timeout, interval).Should(BeNil())
+			})
+		})
+		When("there are not enough schedulable storage nodes", func() {
+			It("should set the PreflightFailed condition on the ManagedOCS resource", func() {
+				node := &corev1.Node{}
+				node.Name = fmt.Sprintf("%s-0", testStorageNodeNamePrefix)
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(node), node)).Should(Succeed())
+				node.Spec.Unschedulable = true
+				Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+
+				// Nodes are not watched, trigger a reconcile through a spec change
+				reconcileInterval := int32(30)
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.FullReconcileIntervalMinutes = &reconcileInterval
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				key := utils.GetResourceKey(managedOCS)
+				Eventually(func() string {
+					Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+					condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionPreflightFailed)
+					if condition == nil || condition.Status != metav1.ConditionTrue {
+						return ""
+					}
+					return condition.Reason
+				}, timeout, interval).Should(Equal(preflightReasonInsufficientNodes))
+
+				// Restore the node and the full reconcile interval
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(node), node)).Should(Succeed())
+				node.Spec.Unschedulable = false
+				Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+
+				Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+				managedOCS.Spec.FullReconcileIntervalMinutes = nil
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() bool {
+					Expect(k8sClient.Get(ctx, key, managedOCS)).Should(Succeed())
+					return meta.IsStatusConditionFalse(managedOCS.Status.Conditions, v1.ConditionPreflightFailed)
+				}, timeout, interval).Should(BeTrue())
+			})
+		})
+		When("the reconcile strategy is changed", func() {
+			It("should record an event on the ManagedOCS resource", func() {
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				Eventually(func() bool {
+					events := &corev1.EventList{}
+					Expect(k8sClient.List(ctx, events, client.InNamespace(testPrimaryNamespace))).Should(Succeed())
+					for _, event := range events.Items {
+						if event.InvolvedObject.UID == managedOCS.UID &&
+							event.Reason == eventReasonReconcileStrategyChanged &&
+							strings.Contains(event.Message, string(v1.ReconcileStrategyNone)) {
+							return true
+						}
+					}
+					return false
+				}, timeout, interval).Should(BeTrue())
+
+				// Restore the default reconcile strategy
+				Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+				managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+			})
+		})
 		When("the prometheus resource is modified", func() {
 			It("should revert the changes and bring the resource back to its managed state", func() {
 				// Get an updated prometheus
@@ -1021,6 +1617,31 @@ var _ = Describe("ManagedOCS controller", func() {
 				}, timeout, interval).Should(Equal(ctrlutils.GetResourceRequirements("ocs-operator")))
 			})
 		})
+		When("the OCS CSV failed to install", func() {
+			It("should report it in the CSVFailed condition until the CSV succeeds", func() {
+				ocsCSV := ocsCSVTemplate.DeepCopy()
+				ocsCSVKey := utils.GetResourceKey(ocsCSV)
+				Expect(k8sClient.Get(ctx, ocsCSVKey, ocsCSV)).Should(Succeed())
+				ocsCSV.Status.Phase = opv1a1.CSVPhaseFailed
+				ocsCSV.Status.Message = "install failed"
+				Expect(k8sClient.Status().Update(ctx, ocsCSV)).Should(Succeed())
+
+				managedOCS := managedOCSTemplate.DeepCopy()
+				Eventually(func() bool {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionCSVFailed)
+				}, timeout, interval).Should(BeTrue())
+
+				Expect(k8sClient.Get(ctx, ocsCSVKey, ocsCSV)).Should(Succeed())
+				ocsCSV.Status.Phase = opv1a1.CSVPhaseSucceeded
+				ocsCSV.Status.Message = ""
+				Expect(k8sClient.Status().Update(ctx, ocsCSV)).Should(Succeed())
+				Eventually(func() *metav1.Condition {
+					Expect(k8sClient.Get(ctx, utils.GetResourceKey(managedOCS), managedOCS)).Should(Succeed())
+					return meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionCSVFailed)
+				}, timeout, interval).Should(BeNil())
+			})
+		})
 		When("the addon config map does not exist while all other uninstall conditions are met", func() {
 			It("should not delete the managedOCS resource", func() {
 				setupUninstallConditions(false, testAddonConfigMapDeleteLabelKey, true, true, true, false, false)
@@ -1139,3 +1760,21 @@ var _ = Describe("ManagedOCS controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Watched namespace validation", func() {
+	When("the namespace exists", func() {
+		It("should succeed", func() {
+			Expect(validateNamespace(k8sClient, testPrimaryNamespace)).Should(Succeed())
+		})
+	})
+	When("the namespace does not exist", func() {
+		It("should fail", func() {
+			Expect(validateNamespace(k8sClient, "missing-namespace")).ShouldNot(Succeed())
+		})
+	})
+	When("the namespace is not set", func() {
+		It("should fail", func() {
+			Expect(validateNamespace(k8sClient, "")).ShouldNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,76 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsControllerName = "managedocs"
+
+	reconcileResultSuccess = "success"
+	reconcileResultRequeue = "requeue"
+	reconcileResultError   = "error"
+
+	reconcilePhaseLoad            = "load"
+	reconcilePhaseReconcilePhases = "reconcilePhases"
+	reconcilePhaseStatusUpdate    = "statusUpdate"
+	reconcilePhasePanic           = "panic"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ocs_osd_deployer_reconcile_duration_seconds",
+			Help:    "Duration of ManagedOCS reconcile loops in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"controller", "result"},
+	)
+	reconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ocs_osd_deployer_reconcile_errors_total",
+			Help: "Total number of errors encountered while reconciling ManagedOCS, by phase",
+		},
+		[]string{"phase"},
+	)
+)
+
+// registerMetrics adds the reconciler metrics to the registry served by the manager's
+// metrics endpoint. Registering the same collectors more than once is not an error.
+func registerMetrics() error {
+	for _, collector := range []prometheus.Collector{reconcileDuration, reconcileErrors} {
+		if err := metrics.Registry.Register(collector); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getReconcileOutcome returns the outcome label of a successful reconcile, before the full
+// reconcile interval is applied to its result
+func getReconcileOutcome(result ctrl.Result) string {
+	if result.Requeue || result.RequeueAfter > 0 {
+		return reconcileResultRequeue
+	}
+	return reconcileResultSuccess
+}
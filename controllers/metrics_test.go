@@ -0,0 +1,33 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Reconcile outcome", func() {
+	It("should count immediate and delayed requeues as requeues", func() {
+		Expect(getReconcileOutcome(ctrl.Result{})).To(Equal(reconcileResultSuccess))
+		Expect(getReconcileOutcome(ctrl.Result{Requeue: true})).To(Equal(reconcileResultRequeue))
+		Expect(getReconcileOutcome(ctrl.Result{RequeueAfter: time.Minute})).To(Equal(reconcileResultRequeue))
+	})
+})
@@ -0,0 +1,252 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	migrationSnapshotSuffix = "-migration-snapshot"
+
+	eventReasonMigrationStarted   = "MigrationStarted"
+	eventReasonMigrationCompleted = "MigrationCompleted"
+)
+
+// MigrationReconciler migrates a StorageCluster deployed without the deployer to the ManagedOCS
+// resource in the same namespace, once spec.migrateFromExisting is set. The StorageCluster spec
+// is snapshotted into a ConfigMap, the StorageCluster is adopted under the none reconcile
+// strategy, and the reconcile strategy is switched to strict once the StorageCluster stayed
+// available for the migration soak duration
+type MigrationReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	recorder record.EventRecorder
+}
+
+// SetupWithManager creates and sets up a MigrationReconciler to work with the provided manager
+func (r *MigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("migration")
+
+	// The StorageCluster to migrate is not owned by the ManagedOCS resource until it is adopted
+	enqueueManagedOCSRequest := handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(
+			func(obj handler.MapObject) []reconcile.Request {
+				return []reconcile.Request{{
+					NamespacedName: types.NamespacedName{
+						Name:      managedOCSName,
+						Namespace: obj.Meta.GetNamespace(),
+					},
+				}}
+			},
+		),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("migration").
+		For(&v1.ManagedOCS{}).
+		Watches(&source.Kind{Type: &ocsv1.StorageCluster{}}, &enqueueManagedOCSRequest).
+		Complete(r)
+}
+
+// Reconcile moves the migration of the StorageCluster of the ManagedOCS resource to its next
+// phase, and requeues until the end of the migration soak
+func (r *MigrationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	managedOCS := &v1.ManagedOCS{}
+	if err := r.Client.Get(ctx, req.NamespacedName, managedOCS); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(-1).Info("ManagedOCS resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !managedOCS.Spec.MigrateFromExisting || !managedOCS.DeletionTimestamp.IsZero() ||
+		managedOCS.Status.MigrationPhase == v1.MigrationPhaseCompleted {
+		return ctrl.Result{}, nil
+	}
+
+	storageCluster := &ocsv1.StorageCluster{}
+	storageClusterKey := types.NamespacedName{Name: getStorageClusterName(managedOCS), Namespace: req.Namespace}
+	if err := r.Client.Get(ctx, storageClusterKey, storageCluster); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("StorageCluster to migrate not found", "name", storageClusterKey.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if managedOCS.Status.MigrationPhase == "" {
+		return ctrl.Result{}, r.startMigration(ctx, managedOCS, storageCluster)
+	}
+	if !isOwnedByManagedOCS(storageCluster) {
+		// The ManagedOCS reconciler adopts the StorageCluster, its owner reference update
+		// triggers the next reconcile
+		log.Info("Waiting for the StorageCluster to be adopted", "name", storageCluster.Name)
+		return ctrl.Result{}, nil
+	}
+	return r.soakMigration(ctx, managedOCS, storageCluster, time.Now())
+}
+
+// startMigration snapshots the spec of the StorageCluster and allows the ManagedOCS reconciler
+// to adopt it under the none reconcile strategy. The snapshot is used as the storage cluster
+// template once the reconcile strategy is switched to strict, unless a template is already set
+func (r *MigrationReconciler) startMigration(ctx context.Context, managedOCS *v1.ManagedOCS, sc *ocsv1.StorageCluster) error {
+	snapshot, err := r.snapshotStorageCluster(ctx, managedOCS, sc)
+	if err != nil {
+		return err
+	}
+
+	managedOCS.Spec.AdoptExistingCluster = true
+	managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
+	if managedOCS.Spec.StorageClusterTemplate == nil {
+		managedOCS.Spec.StorageClusterTemplate = &corev1.LocalObjectReference{Name: snapshot.Name}
+	}
+	if err := r.Client.Update(ctx, managedOCS); err != nil {
+		return fmt.Errorf("unable to prepare the ManagedOCS resource for the migration: %w", err)
+	}
+
+	managedOCS.Status.MigrationPhase = v1.MigrationPhaseAdopting
+	if err := r.Client.Status().Update(ctx, managedOCS); err != nil {
+		return fmt.Errorf("unable to update the migration phase: %w", err)
+	}
+	r.Log.Info("StorageCluster migration started", "name", sc.Name, "snapshot", snapshot.Name)
+	r.recordEvent(managedOCS, corev1.EventTypeNormal, eventReasonMigrationStarted,
+		"StorageCluster %v spec snapshotted into ConfigMap %v, adopting it with reconcile strategy %v",
+		sc.Name, snapshot.Name, v1.ReconcileStrategyNone)
+	return nil
+}
+
+// snapshotStorageCluster saves the spec of the StorageCluster into a ConfigMap owned by the
+// ManagedOCS resource, in the format of a storage cluster template. An existing snapshot is
+// kept, so that a retried migration does not overwrite it with an adopted spec
+func (r *MigrationReconciler) snapshotStorageCluster(ctx context.Context, managedOCS *v1.ManagedOCS, sc *ocsv1.StorageCluster) (*corev1.ConfigMap, error) {
+	snapshot := &corev1.ConfigMap{}
+	snapshot.Name = managedOCS.Name + migrationSnapshotSuffix
+	snapshot.Namespace = managedOCS.Namespace
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: snapshot.Name, Namespace: snapshot.Namespace}, snapshot); err == nil {
+		return snapshot, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get migration snapshot ConfigMap %v: %w", snapshot.Name, err)
+	}
+
+	// JSON is valid YAML, the template can be read back without a conversion
+	specJSON, err := json.Marshal(&sc.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal StorageCluster spec: %w", err)
+	}
+	snapshot.Data = map[string]string{storageClusterTemplateKey: string(specJSON)}
+	if err := ctrl.SetControllerReference(managedOCS, snapshot, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("unable to create migration snapshot ConfigMap %v: %w", snapshot.Name, err)
+	}
+	return snapshot, nil
+}
+
+// soakMigration switches the reconcile strategy to strict once the adopted StorageCluster
+// stayed healthy for the migration soak duration. The soak restarts whenever the
+// StorageCluster is not healthy
+func (r *MigrationReconciler) soakMigration(ctx context.Context, managedOCS *v1.ManagedOCS,
+	sc *ocsv1.StorageCluster, now time.Time) (ctrl.Result, error) {
+	if !isStorageClusterHealthy(sc) {
+		r.Log.Info("Migrated StorageCluster is not healthy, waiting to start the soak", "name", sc.Name)
+		if managedOCS.Status.MigrationPhase == v1.MigrationPhaseSoaking && managedOCS.Status.MigrationSoakStartTime == nil {
+			return ctrl.Result{}, nil
+		}
+		managedOCS.Status.MigrationPhase = v1.MigrationPhaseSoaking
+		managedOCS.Status.MigrationSoakStartTime = nil
+		return ctrl.Result{}, r.Client.Status().Update(ctx, managedOCS)
+	}
+
+	soakDuration := getMigrationSoakDuration(managedOCS)
+	if managedOCS.Status.MigrationSoakStartTime == nil {
+		managedOCS.Status.MigrationPhase = v1.MigrationPhaseSoaking
+		managedOCS.Status.MigrationSoakStartTime = &metav1.Time{Time: now}
+		if err := r.Client.Status().Update(ctx, managedOCS); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: soakDuration}, nil
+	}
+	if remaining := soakDuration - now.Sub(managedOCS.Status.MigrationSoakStartTime.Time); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+	if err := r.Client.Update(ctx, managedOCS); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to switch the migrated StorageCluster to reconcile strategy %v: %w",
+			v1.ReconcileStrategyStrict, err)
+	}
+	managedOCS.Status.MigrationPhase = v1.MigrationPhaseCompleted
+	if err := r.Client.Status().Update(ctx, managedOCS); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update the migration phase: %w", err)
+	}
+	r.Log.Info("StorageCluster migration completed", "name", sc.Name)
+	r.recordEvent(managedOCS, corev1.EventTypeNormal, eventReasonMigrationCompleted,
+		"StorageCluster %v stayed healthy for %v, reconcile strategy set to %v",
+		sc.Name, soakDuration, v1.ReconcileStrategyStrict)
+	return ctrl.Result{}, nil
+}
+
+func (r *MigrationReconciler) recordEvent(managedOCS *v1.ManagedOCS, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.recorder != nil {
+		r.recorder.Eventf(managedOCS, eventType, reason, messageFmt, args...)
+	}
+}
+
+// getMigrationSoakDuration returns the migration soak duration of managedOCS, or the default
+// duration when it does not set one
+func getMigrationSoakDuration(managedOCS *v1.ManagedOCS) time.Duration {
+	if soakDuration := managedOCS.Spec.MigrationSoakDuration; soakDuration != nil {
+		return soakDuration.Duration
+	}
+	return v1.DefaultMigrationSoakDuration
+}
+
+// isStorageClusterHealthy checks that the StorageCluster is available and is neither in the
+// Error phase nor degraded
+func isStorageClusterHealthy(storageCluster *ocsv1.StorageCluster) bool {
+	return isStorageClusterAvailable(storageCluster) &&
+		storageCluster.Status.Phase != utils.PhaseError &&
+		!conditionsv1.IsStatusConditionTrue(storageCluster.Status.Conditions, conditionsv1.ConditionDegraded)
+}
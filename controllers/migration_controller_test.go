@@ -0,0 +1,166 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// migrationClient finds no object and records the created objects and the updates
+type migrationClient struct {
+	client.Client
+	created       []runtime.Object
+	updates       int
+	statusUpdates int
+}
+
+func (c *migrationClient) Get(_ context.Context, key client.ObjectKey, _ runtime.Object) error {
+	return errors.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+func (c *migrationClient) Create(_ context.Context, obj runtime.Object, _ ...client.CreateOption) error {
+	c.created = append(c.created, obj)
+	return nil
+}
+
+func (c *migrationClient) Update(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+	c.updates++
+	return nil
+}
+
+func (c *migrationClient) Status() client.StatusWriter {
+	return &migrationStatusWriter{c}
+}
+
+type migrationStatusWriter struct {
+	*migrationClient
+}
+
+func (w *migrationStatusWriter) Update(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+	w.statusUpdates++
+	return nil
+}
+
+var _ = Describe("StorageCluster migration", func() {
+	var c *migrationClient
+	var reconciler *MigrationReconciler
+	var managedOCS *v1.ManagedOCS
+	var storageCluster *ocsv1.StorageCluster
+	now := time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		c = &migrationClient{}
+		reconciler = &MigrationReconciler{
+			Client: c,
+			Log:    ctrl.Log.WithName("test"),
+			Scheme: scheme,
+		}
+
+		managedOCS = &v1.ManagedOCS{}
+		managedOCS.Name = managedOCSName
+		managedOCS.Namespace = "primary"
+		managedOCS.Spec.MigrateFromExisting = true
+		managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+
+		storageCluster = &ocsv1.StorageCluster{}
+		storageCluster.Name = v1.DefaultStorageClusterName
+		storageCluster.Spec.ManageNodes = true
+		storageCluster.Status.Conditions = []conditionsv1.Condition{
+			{Type: conditionsv1.ConditionAvailable, Status: corev1.ConditionTrue},
+		}
+	})
+
+	It("should snapshot the StorageCluster spec and adopt it with the none reconcile strategy", func() {
+		Expect(reconciler.startMigration(context.Background(), managedOCS, storageCluster)).To(Succeed())
+
+		Expect(c.created).To(HaveLen(1))
+		snapshot := c.created[0].(*corev1.ConfigMap)
+		Expect(snapshot.Name).To(Equal(managedOCSName + migrationSnapshotSuffix))
+		Expect(snapshot.Data[storageClusterTemplateKey]).To(ContainSubstring(`"manageNodes":true`))
+		Expect(snapshot.OwnerReferences).To(HaveLen(1))
+
+		Expect(managedOCS.Spec.AdoptExistingCluster).To(BeTrue())
+		Expect(managedOCS.Spec.ReconcileStrategy).To(Equal(v1.ReconcileStrategyNone))
+		Expect(managedOCS.Spec.StorageClusterTemplate).To(Equal(&corev1.LocalObjectReference{Name: snapshot.Name}))
+		Expect(managedOCS.Status.MigrationPhase).To(Equal(v1.MigrationPhaseAdopting))
+	})
+
+	It("should keep the storage cluster template already set", func() {
+		managedOCS.Spec.StorageClusterTemplate = &corev1.LocalObjectReference{Name: "custom-template"}
+		Expect(reconciler.startMigration(context.Background(), managedOCS, storageCluster)).To(Succeed())
+		Expect(managedOCS.Spec.StorageClusterTemplate.Name).To(Equal("custom-template"))
+	})
+
+	It("should start the soak once the adopted StorageCluster is healthy", func() {
+		managedOCS.Status.MigrationPhase = v1.MigrationPhaseAdopting
+		result, err := reconciler.soakMigration(context.Background(), managedOCS, storageCluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(v1.DefaultMigrationSoakDuration))
+		Expect(managedOCS.Status.MigrationPhase).To(Equal(v1.MigrationPhaseSoaking))
+		Expect(managedOCS.Status.MigrationSoakStartTime.Time).To(Equal(now))
+	})
+
+	It("should requeue until the end of the soak", func() {
+		managedOCS.Spec.MigrationSoakDuration = &metav1.Duration{Duration: time.Hour}
+		managedOCS.Status.MigrationPhase = v1.MigrationPhaseSoaking
+		managedOCS.Status.MigrationSoakStartTime = &metav1.Time{Time: now.Add(-20 * time.Minute)}
+		result, err := reconciler.soakMigration(context.Background(), managedOCS, storageCluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(40 * time.Minute))
+		Expect(c.updates + c.statusUpdates).To(BeZero())
+	})
+
+	It("should restart the soak when the StorageCluster is degraded", func() {
+		storageCluster.Status.Conditions = append(storageCluster.Status.Conditions,
+			conditionsv1.Condition{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionTrue})
+		managedOCS.Status.MigrationPhase = v1.MigrationPhaseSoaking
+		managedOCS.Status.MigrationSoakStartTime = &metav1.Time{Time: now.Add(-time.Hour)}
+		_, err := reconciler.soakMigration(context.Background(), managedOCS, storageCluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(managedOCS.Status.MigrationSoakStartTime).To(BeNil())
+		Expect(c.statusUpdates).To(Equal(1))
+	})
+
+	It("should switch to the strict reconcile strategy once the soak ended", func() {
+		managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
+		managedOCS.Status.MigrationPhase = v1.MigrationPhaseSoaking
+		managedOCS.Status.MigrationSoakStartTime = &metav1.Time{Time: now.Add(-v1.DefaultMigrationSoakDuration)}
+		result, err := reconciler.soakMigration(context.Background(), managedOCS, storageCluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(managedOCS.Spec.ReconcileStrategy).To(Equal(v1.ReconcileStrategyStrict))
+		Expect(managedOCS.Status.MigrationPhase).To(Equal(v1.MigrationPhaseCompleted))
+		Expect(c.updates).To(Equal(1))
+	})
+})
@@ -0,0 +1,169 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+const (
+	cephNetworkPolicyPrefix = "managed-ocs-ceph-"
+	cephAppLabelKey         = "app"
+
+	// Ports of the Ceph mon messenger v2 and v1 protocols, and of the mgr Prometheus module
+	cephMonMsgr2Port  = 3300
+	cephMonMsgr1Port  = 6789
+	cephMgrMetricPort = 9283
+)
+
+// cephNetworkPolicyComponents lists the Ceph components isolated by a NetworkPolicy, with the
+// app label of their pods. OSDs reach the mons on the mon ports, while the osd, mds and mgr
+// daemons, and their clients, use a dynamic port range that the networking/v1 API cannot
+// express, so all their ports are open to the namespace
+var cephNetworkPolicyComponents = []struct {
+	name     string
+	appLabel string
+	ports    []int
+}{
+	{"mon", "rook-ceph-mon", []int{cephMonMsgr2Port, cephMonMsgr1Port}},
+	{"osd", "rook-ceph-osd", nil},
+	{"mds", "rook-ceph-mds", nil},
+	{"mgr", "rook-ceph-mgr", nil},
+}
+
+// NetworkPolicyReconciler creates the NetworkPolicies isolating the Ceph pods when network
+// isolation is enabled in the ManagedOCS spec, and deletes them once it is disabled
+type NetworkPolicyReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups="networking.k8s.io",namespace=system,resources=networkpolicies,verbs=get;list;watch;create;update;delete
+
+// SetupWithManager creates and sets up a NetworkPolicyReconciler to work with the provided manager
+func (r *NetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("networkpolicy").
+		For(&v1.ManagedOCS{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Complete(r)
+}
+
+// Reconcile creates, updates or deletes the Ceph NetworkPolicies of the ManagedOCS resource
+func (r *NetworkPolicyReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	managedOCS := &v1.ManagedOCS{}
+	if err := r.Client.Get(ctx, req.NamespacedName, managedOCS); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(-1).Info("ManagedOCS resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	desired := getCephNetworkPolicies(req.Namespace)
+	if !managedOCS.Spec.NetworkIsolation || !managedOCS.DeletionTimestamp.IsZero() {
+		for i := range desired {
+			if err := r.Client.Delete(ctx, &desired[i]); err != nil && !errors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("unable to delete NetworkPolicy %v: %w", desired[i].Name, err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	for i := range desired {
+		networkPolicy := &networkingv1.NetworkPolicy{}
+		networkPolicy.Name = desired[i].Name
+		networkPolicy.Namespace = desired[i].Namespace
+		result, err := ctrl.CreateOrUpdate(ctx, r.Client, networkPolicy, func() error {
+			if err := ctrl.SetControllerReference(managedOCS, networkPolicy, r.Scheme); err != nil {
+				return err
+			}
+			networkPolicy.Spec = desired[i].Spec
+			return nil
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to reconcile NetworkPolicy %v: %w", networkPolicy.Name, err)
+		}
+		if result != controllerutil.OperationResultNone {
+			log.Info("NetworkPolicy reconciled", "name", networkPolicy.Name, "result", result)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// getCephNetworkPolicies returns the desired NetworkPolicies of the Ceph components. Each one
+// only allows the ingress traffic from the pods in namespace, on the ports of the component,
+// and the mgr one also allows the scraping of its metrics from all namespaces
+func getCephNetworkPolicies(namespace string) []networkingv1.NetworkPolicy {
+	networkPolicies := make([]networkingv1.NetworkPolicy, 0, len(cephNetworkPolicyComponents))
+	for _, component := range cephNetworkPolicyComponents {
+		networkPolicy := networkingv1.NetworkPolicy{}
+		networkPolicy.Name = cephNetworkPolicyPrefix + component.name
+		networkPolicy.Namespace = namespace
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{cephAppLabelKey: component.appLabel},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+				Ports: getTCPNetworkPolicyPorts(component.ports...),
+			}},
+		}
+		if component.name == "mgr" {
+			networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+				From:  []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+				Ports: getTCPNetworkPolicyPorts(cephMgrMetricPort),
+			})
+		}
+		networkPolicies = append(networkPolicies, networkPolicy)
+	}
+	return networkPolicies
+}
+
+// getTCPNetworkPolicyPorts returns the NetworkPolicy ports matching the TCP ports, or nil, which
+// matches all the ports, when there are none
+func getTCPNetworkPolicyPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	if len(ports) == 0 {
+		return nil
+	}
+	protocol := corev1.ProtocolTCP
+	networkPolicyPorts := make([]networkingv1.NetworkPolicyPort, len(ports))
+	for i, port := range ports {
+		portValue := intstr.FromInt(port)
+		networkPolicyPorts[i] = networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &portValue}
+	}
+	return networkPolicyPorts
+}
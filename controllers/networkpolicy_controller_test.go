@@ -0,0 +1,64 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Ceph NetworkPolicies", func() {
+	findNetworkPolicy := func(networkPolicies []networkingv1.NetworkPolicy, name string) *networkingv1.NetworkPolicy {
+		for i := range networkPolicies {
+			if networkPolicies[i].Name == name {
+				return &networkPolicies[i]
+			}
+		}
+		return nil
+	}
+
+	It("should isolate the mon, osd, mds and mgr pods of the namespace", func() {
+		networkPolicies := getCephNetworkPolicies("primary")
+		Expect(networkPolicies).To(HaveLen(4))
+		for _, component := range []string{"mon", "osd", "mds", "mgr"} {
+			networkPolicy := findNetworkPolicy(networkPolicies, cephNetworkPolicyPrefix+component)
+			Expect(networkPolicy).ToNot(BeNil())
+			Expect(networkPolicy.Namespace).To(Equal("primary"))
+			Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue(cephAppLabelKey, "rook-ceph-"+component))
+			Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+			Expect(networkPolicy.Spec.Ingress[0].From[0].PodSelector).ToNot(BeNil())
+		}
+	})
+
+	It("should only open the mon ports of the mon pods", func() {
+		mon := findNetworkPolicy(getCephNetworkPolicies("primary"), cephNetworkPolicyPrefix+"mon")
+		Expect(mon.Spec.Ingress).To(HaveLen(1))
+		Expect(mon.Spec.Ingress[0].Ports).To(HaveLen(2))
+		Expect(*mon.Spec.Ingress[0].Ports[0].Port).To(Equal(intstr.FromInt(cephMonMsgr2Port)))
+		Expect(*mon.Spec.Ingress[0].Ports[1].Port).To(Equal(intstr.FromInt(cephMonMsgr1Port)))
+	})
+
+	It("should open the mgr metrics port to all namespaces", func() {
+		mgr := findNetworkPolicy(getCephNetworkPolicies("primary"), cephNetworkPolicyPrefix+"mgr")
+		Expect(mgr.Spec.Ingress).To(HaveLen(2))
+		Expect(mgr.Spec.Ingress[0].Ports).To(BeEmpty())
+		Expect(mgr.Spec.Ingress[1].From[0].NamespaceSelector).ToNot(BeNil())
+		Expect(*mgr.Spec.Ingress[1].Ports[0].Port).To(Equal(intstr.FromInt(cephMgrMetricPort)))
+	})
+})
@@ -0,0 +1,82 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	rook "github.com/rook/rook/pkg/apis/rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Storage device set node selector", func() {
+	templatePlacement := &rook.Placement{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node-role.kubernetes.io/worker",
+						Operator: corev1.NodeSelectorOpExists,
+					}},
+				}},
+			},
+		},
+	}
+
+	When("the node selector is nil", func() {
+		It("should keep the placement of the template", func() {
+			spec := newTestStorageClusterSpec(templatePlacement)
+			applyNodeSelector(spec, nil)
+			Expect(spec).To(Equal(newTestStorageClusterSpec(templatePlacement)))
+		})
+	})
+
+	When("the node selector is empty", func() {
+		It("should keep the placement of the template", func() {
+			spec := newTestStorageClusterSpec(templatePlacement)
+			applyNodeSelector(spec, map[string]string{})
+			Expect(spec).To(Equal(newTestStorageClusterSpec(templatePlacement)))
+		})
+	})
+
+	When("the node selector has multiple labels", func() {
+		It("should require all the labels on every storage device set, sorted by key", func() {
+			spec := newTestStorageClusterSpec(templatePlacement)
+			applyNodeSelector(spec, map[string]string{
+				"topology.kubernetes.io/zone":      "us-east-1a",
+				"cluster.ocs.openshift.io/storage": "",
+			})
+
+			expected := []corev1.NodeSelectorRequirement{{
+				Key:      "cluster.ocs.openshift.io/storage",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{""},
+			}, {
+				Key:      "topology.kubernetes.io/zone",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"us-east-1a"},
+			}}
+			Expect(spec.StorageDeviceSets).To(HaveLen(2))
+			for _, deviceSet := range spec.StorageDeviceSets {
+				terms := deviceSet.Placement.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				Expect(terms).To(HaveLen(1))
+				Expect(terms[0].MatchExpressions).To(Equal(expected))
+				Expect(deviceSet.Placement.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+			}
+		})
+	})
+})
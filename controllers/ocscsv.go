@@ -0,0 +1,75 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	opv1a1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+// csvRetryInterval is the interval at which the phase of an OCS CSV that is still being
+// installed is checked again
+const csvRetryInterval = 30 * time.Second
+
+// checkOCSCSVReady verifies that the OCS CSV has been installed successfully before the
+// StorageCluster is reconciled. It returns false while the CSV is missing or not installed yet,
+// and an error if the CSV failed, which is reported in the CSVFailed condition.
+func (r *ManagedOCSReconciler) checkOCSCSVReady(ctx context.Context) (bool, error) {
+	csvList := opv1a1.ClusterServiceVersionList{}
+	if err := r.list(ctx, &csvList); err != nil {
+		return false, fmt.Errorf("unable to list csv resources: %w", err)
+	}
+	csv := getCSVByPrefix(csvList, ocsOperatorName)
+	if csv == nil {
+		r.Log.Info("OCS CSV not found")
+		return false, nil
+	}
+
+	switch csv.Status.Phase {
+	case opv1a1.CSVPhaseSucceeded:
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionCSVFailed)
+		return true, nil
+	case opv1a1.CSVPhaseFailed:
+		reason := string(csv.Status.Reason)
+		if reason == "" {
+			reason = "InstallFailed"
+		}
+		meta.SetStatusCondition(&r.managedOCS.Status.Conditions, metav1.Condition{
+			Type:               v1.ConditionCSVFailed,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: r.managedOCS.Generation,
+			Reason:             reason,
+			Message:            csv.Status.Message,
+		})
+		// The error is not retriable so the backoff does not spin on a failed install, the CSV
+		// is watched and triggers a reconcile once OLM recovers it
+		err := fmt.Errorf("OCS CSV %v failed: %v", csv.Name, csv.Status.Message)
+		return false, deployererrors.NewReadinessError(csv.Name, reason, false, err)
+	default:
+		r.Log.Info("OCS CSV is not installed yet", "name", csv.Name, "phase", csv.Status.Phase)
+		return false, nil
+	}
+}
@@ -0,0 +1,96 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/templates"
+)
+
+const (
+	cephMgrServiceMonitorName            = "managed-ocs-ceph-mgr"
+	ocsMetricsExporterServiceMonitorName = "managed-ocs-metrics-exporter"
+)
+
+// getOCSMetricsServiceMonitors returns the desired ServiceMonitors scraping the metrics of the
+// OCS components in namespace, at the interval of the monitoring spec
+func getOCSMetricsServiceMonitors(namespace string, monitoringSpec *v1.MonitoringSpec) []promv1.ServiceMonitor {
+	interval := fmt.Sprintf("%ds", getScrapeIntervalSeconds(monitoringSpec))
+	serviceMonitors := []promv1.ServiceMonitor{
+		*templates.CephMgrServiceMonitorTemplate.DeepCopy(),
+		*templates.OCSMetricsExporterServiceMonitorTemplate.DeepCopy(),
+	}
+	serviceMonitors[0].Name = cephMgrServiceMonitorName
+	serviceMonitors[1].Name = ocsMetricsExporterServiceMonitorName
+	for i := range serviceMonitors {
+		serviceMonitors[i].Namespace = namespace
+		for j := range serviceMonitors[i].Spec.Endpoints {
+			serviceMonitors[i].Spec.Endpoints[j].Interval = interval
+		}
+	}
+	return serviceMonitors
+}
+
+// getScrapeIntervalSeconds returns the scrape interval of the monitoring spec, or the default
+// interval when it does not set one
+func getScrapeIntervalSeconds(monitoringSpec *v1.MonitoringSpec) int32 {
+	if monitoringSpec == nil || monitoringSpec.ScrapeIntervalSeconds <= 0 {
+		return v1.DefaultScrapeIntervalSeconds
+	}
+	return monitoringSpec.ScrapeIntervalSeconds
+}
+
+// reconcileOCSMetricsServiceMonitors creates the ServiceMonitors scraping the metrics of the OCS
+// components when monitoring is enabled in the ManagedOCS spec, and deletes them once it is
+// disabled. They are labeled for the Prometheus of the deployer by reconcileMonitoringResources
+func (r *ManagedOCSReconciler) reconcileOCSMetricsServiceMonitors(ctx context.Context) error {
+	r.Log.Info("Reconciling OCS metrics ServiceMonitors")
+
+	monitoringSpec := r.managedOCS.Spec.MonitoringSpec
+	desired := getOCSMetricsServiceMonitors(r.namespace, monitoringSpec)
+	if monitoringSpec == nil || !monitoringSpec.Enabled {
+		for i := range desired {
+			if err := r.delete(ctx, &desired[i]); err != nil {
+				return fmt.Errorf("Failed to delete ServiceMonitor %v: %w", desired[i].Name, err)
+			}
+		}
+		return nil
+	}
+
+	for i := range desired {
+		serviceMonitor := &promv1.ServiceMonitor{}
+		serviceMonitor.Name = desired[i].Name
+		serviceMonitor.Namespace = desired[i].Namespace
+		_, err := ctrl.CreateOrUpdate(ctx, r.Client, serviceMonitor, func() error {
+			if err := r.own(serviceMonitor); err != nil {
+				return err
+			}
+			serviceMonitor.Spec = desired[i].Spec
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to update ServiceMonitor %v: %w", serviceMonitor.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("OCS metrics ServiceMonitors", func() {
+	It("should scrape the Ceph mgr and the metrics exporter at the default interval", func() {
+		serviceMonitors := getOCSMetricsServiceMonitors("primary", &v1.MonitoringSpec{Enabled: true})
+		Expect(serviceMonitors).To(HaveLen(2))
+		Expect(serviceMonitors[0].Name).To(Equal(cephMgrServiceMonitorName))
+		Expect(serviceMonitors[0].Spec.Selector.MatchLabels).To(HaveKeyWithValue("app", "rook-ceph-mgr"))
+		Expect(serviceMonitors[1].Name).To(Equal(ocsMetricsExporterServiceMonitorName))
+		for _, serviceMonitor := range serviceMonitors {
+			Expect(serviceMonitor.Namespace).To(Equal("primary"))
+			Expect(serviceMonitor.Spec.Endpoints).To(HaveLen(1))
+			Expect(serviceMonitor.Spec.Endpoints[0].Interval).To(Equal("30s"))
+		}
+	})
+
+	It("should scrape at the interval of the monitoring spec", func() {
+		serviceMonitors := getOCSMetricsServiceMonitors("primary", &v1.MonitoringSpec{ScrapeIntervalSeconds: 90})
+		Expect(serviceMonitors[0].Spec.Endpoints[0].Interval).To(Equal("90s"))
+	})
+
+	It("should not change the templates", func() {
+		getOCSMetricsServiceMonitors("primary", &v1.MonitoringSpec{ScrapeIntervalSeconds: 90})
+		serviceMonitors := getOCSMetricsServiceMonitors("primary", nil)
+		Expect(serviceMonitors[0].Spec.Endpoints[0].Interval).To(Equal("30s"))
+	})
+})
@@ -0,0 +1,71 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	opv1a1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+// checkOCSSubscription verifies that the OLM Subscription of the OCS operator is healthy before
+// the StorageCluster is reconciled. It returns an error if the Subscription failed to upgrade,
+// which is reported in the SubscriptionFailed condition. A missing Subscription is left to the
+// check of the OCS CSV.
+func (r *ManagedOCSReconciler) checkOCSSubscription(ctx context.Context) error {
+	subscriptionList := opv1a1.SubscriptionList{}
+	if err := r.list(ctx, &subscriptionList); err != nil {
+		return fmt.Errorf("unable to list subscription resources: %w", err)
+	}
+	subscription := getSubscriptionByPackage(subscriptionList, ocsOperatorName)
+	if subscription == nil || subscription.Status.State != opv1a1.SubscriptionStateFailed {
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionSubscriptionFailed)
+		return nil
+	}
+
+	reason := string(subscription.Status.Reason)
+	if reason == "" {
+		reason = opv1a1.SubscriptionStateFailed
+	}
+	meta.SetStatusCondition(&r.managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionSubscriptionFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: r.managedOCS.Generation,
+		Reason:             reason,
+		Message:            fmt.Sprintf("OCS Subscription %v failed to upgrade to %v", subscription.Name, subscription.Status.CurrentCSV),
+	})
+	// OLM retries the upgrade once the failed install plan is replaced
+	err := fmt.Errorf("OCS Subscription %v is in the %v state", subscription.Name, subscription.Status.State)
+	return deployererrors.NewReadinessError(subscription.Name, reason, true, err)
+}
+
+func getSubscriptionByPackage(subscriptionList opv1a1.SubscriptionList, packageName string) *opv1a1.Subscription {
+	for i := range subscriptionList.Items {
+		subscription := &subscriptionList.Items[i]
+		if subscription.Spec != nil && subscription.Spec.Package == packageName {
+			return subscription
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	opv1a1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+)
+
+// subscriptionClient lists a fixed set of subscriptions, other calls are not expected
+type subscriptionClient struct {
+	client.Client
+	subscriptions []opv1a1.Subscription
+}
+
+func (c *subscriptionClient) List(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+	list.(*opv1a1.SubscriptionList).Items = c.subscriptions
+	return nil
+}
+
+var _ = Describe("OCS Subscription check", func() {
+	var reconciler *ManagedOCSReconciler
+	var ocsSubscription opv1a1.Subscription
+
+	BeforeEach(func() {
+		deployerSubscription := opv1a1.Subscription{Spec: &opv1a1.SubscriptionSpec{Package: "ocs-osd-deployer"}}
+		deployerSubscription.Name = "addon-ocs-converged"
+		deployerSubscription.Status.State = opv1a1.SubscriptionStateFailed
+		ocsSubscription = opv1a1.Subscription{Spec: &opv1a1.SubscriptionSpec{Package: ocsOperatorName}}
+		ocsSubscription.Name = "ocs-operator-stable"
+		ocsSubscription.Status.State = opv1a1.SubscriptionStateAtLatest
+
+		reconciler = &ManagedOCSReconciler{
+			Client: &subscriptionClient{subscriptions: []opv1a1.Subscription{deployerSubscription}},
+			Log:    ctrl.Log.WithName("test"),
+		}
+		reconciler.managedOCS = &v1.ManagedOCS{}
+	})
+
+	It("should pass without an OCS Subscription", func() {
+		Expect(reconciler.checkOCSSubscription(context.Background())).To(Succeed())
+		Expect(meta.FindStatusCondition(reconciler.managedOCS.Status.Conditions, v1.ConditionSubscriptionFailed)).To(BeNil())
+	})
+
+	It("should pass while the OCS Subscription is healthy", func() {
+		subscriptions := reconciler.Client.(*subscriptionClient)
+		for _, state := range []opv1a1.SubscriptionState{opv1a1.SubscriptionStateAtLatest, opv1a1.SubscriptionStateUpgradePending} {
+			ocsSubscription.Status.State = state
+			subscriptions.subscriptions = append(subscriptions.subscriptions[:1], ocsSubscription)
+			Expect(reconciler.checkOCSSubscription(context.Background())).To(Succeed(), "state %v", state)
+		}
+	})
+
+	It("should set the SubscriptionFailed condition and fail with a retryable error once the upgrade failed", func() {
+		subscriptions := reconciler.Client.(*subscriptionClient)
+		ocsSubscription.Status.State = opv1a1.SubscriptionStateFailed
+		subscriptions.subscriptions = append(subscriptions.subscriptions, ocsSubscription)
+
+		err := reconciler.checkOCSSubscription(context.Background())
+		Expect(err).To(HaveOccurred())
+		reconcileErr, ok := deployererrors.AsReconcileError(err)
+		Expect(ok).To(BeTrue())
+		Expect(reconcileErr.Retryable).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(reconciler.managedOCS.Status.Conditions, v1.ConditionSubscriptionFailed)).To(BeTrue())
+
+		ocsSubscription.Status.State = opv1a1.SubscriptionStateAtLatest
+		subscriptions.subscriptions[1] = ocsSubscription
+		Expect(reconciler.checkOCSSubscription(context.Background())).To(Succeed())
+		Expect(meta.FindStatusCondition(reconciler.managedOCS.Status.Conditions, v1.ConditionSubscriptionFailed)).To(BeNil())
+	})
+})
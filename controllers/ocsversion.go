@@ -0,0 +1,108 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	opv1a1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	versionReasonInRange    = "VersionInRange"
+	versionReasonOutOfRange = "VersionOutOfRange"
+	versionReasonUnknown    = "VersionUnknown"
+)
+
+// checkOCSVersion verifies that the installed OCS operator version is within the range set in
+// the ManagedOCS spec and reports the outcome in the VersionMismatch condition. It returns
+// false if the version is out of range or unknown, in which case the StorageCluster must not
+// be created or updated. The OCS CSV is watched, so upgrades trigger a new check.
+func (r *ManagedOCSReconciler) checkOCSVersion(ctx context.Context) (bool, error) {
+	minVersion := r.managedOCS.Spec.MinOCSVersion
+	maxVersion := r.managedOCS.Spec.MaxOCSVersion
+	if minVersion == "" && maxVersion == "" {
+		utils.RemoveStatusCondition(&r.managedOCS.Status.Conditions, v1.ConditionVersionMismatch)
+		return true, nil
+	}
+
+	csvList := opv1a1.ClusterServiceVersionList{}
+	if err := r.list(ctx, &csvList); err != nil {
+		return false, fmt.Errorf("unable to list csv resources: %w", err)
+	}
+	csv := getCSVByPrefix(csvList, ocsOperatorName)
+	if csv == nil {
+		r.setVersionMismatchCondition(metav1.ConditionTrue, versionReasonUnknown, "The OCS CSV was not found")
+		return false, nil
+	}
+
+	version := csv.Spec.Version.Version
+	inRange, err := isVersionInRange(version, minVersion, maxVersion)
+	if err != nil {
+		return false, err
+	}
+	if !inRange {
+		r.setVersionMismatchCondition(metav1.ConditionTrue, versionReasonOutOfRange, fmt.Sprintf(
+			"The installed OCS version %v is outside of the supported range [%q, %q]",
+			version, minVersion, maxVersion))
+		return false, nil
+	}
+	r.setVersionMismatchCondition(metav1.ConditionFalse, versionReasonInRange, fmt.Sprintf(
+		"The installed OCS version %v is supported", version))
+	return true, nil
+}
+
+func (r *ManagedOCSReconciler) setVersionMismatchCondition(status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&r.managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionVersionMismatch,
+		Status:             status,
+		ObservedGeneration: r.managedOCS.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// isVersionInRange checks whether version is between the inclusive minVersion and maxVersion
+// bounds, an empty bound is not enforced
+func isVersionInRange(version semver.Version, minVersion string, maxVersion string) (bool, error) {
+	if minVersion != "" {
+		min, err := semver.ParseTolerant(minVersion)
+		if err != nil {
+			return false, fmt.Errorf("invalid minimum OCS version %q: %w", minVersion, err)
+		}
+		if version.LT(min) {
+			return false, nil
+		}
+	}
+	if maxVersion != "" {
+		max, err := semver.ParseTolerant(maxVersion)
+		if err != nil {
+			return false, fmt.Errorf("invalid maximum OCS version %q: %w", maxVersion, err)
+		}
+		if version.GT(max) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
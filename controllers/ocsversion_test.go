@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/blang/semver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OCS version constraint", func() {
+	version := semver.MustParse("4.7.2")
+
+	When("no bound is set", func() {
+		It("should accept any version", func() {
+			Expect(isVersionInRange(version, "", "")).To(BeTrue())
+		})
+	})
+	When("the version is within the bounds", func() {
+		It("should accept the version", func() {
+			Expect(isVersionInRange(version, "4.7", "4.8")).To(BeTrue())
+			Expect(isVersionInRange(version, "4.7.2", "4.7.2")).To(BeTrue())
+			Expect(isVersionInRange(version, "v4.6.0", "")).To(BeTrue())
+		})
+	})
+	When("the version is outside of the bounds", func() {
+		It("should reject the version", func() {
+			Expect(isVersionInRange(version, "4.8", "")).To(BeFalse())
+			Expect(isVersionInRange(version, "", "4.7.1")).To(BeFalse())
+		})
+	})
+	When("a bound is not a version", func() {
+		It("should fail", func() {
+			_, err := isVersionInRange(version, "latest", "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+const (
+	operatorConfigName = "ocs-osd-deployer-config"
+
+	defaultFullReconcileInterval = 60 * time.Minute
+)
+
+// loadOperatorConfig reads the OperatorConfig of the namespace, if there is one, and applies its
+// log level. A missing OperatorConfig keeps the built-in behavior. The log level of the ManagedOCS
+// spec, applied later in the reconcile, takes precedence.
+func (r *ManagedOCSReconciler) loadOperatorConfig(ctx context.Context) error {
+	r.operatorConfig = v1.OperatorConfigSpec{}
+
+	operatorConfig := &v1.OperatorConfig{}
+	operatorConfig.Name = operatorConfigName
+	operatorConfig.Namespace = r.namespace
+	if err := r.get(ctx, operatorConfig); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	r.operatorConfig = operatorConfig.Spec
+
+	if r.LogLevel != nil && r.operatorConfig.LogLevel != "" {
+		if _, err := SetLogLevel(r.LogLevel, r.operatorConfig.LogLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLogLevel adjusts logLevel, shared by the loggers of the deployer, to the named level. It
+// reports whether the level changed
+func SetLogLevel(logLevel *zap.AtomicLevel, name string) (bool, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return false, err
+	}
+	if logLevel.Level() == level {
+		return false, nil
+	}
+	logLevel.SetLevel(level)
+	return true, nil
+}
+
+// applySpecLogLevel applies the log level of the ManagedOCS spec, if set
+func (r *ManagedOCSReconciler) applySpecLogLevel() {
+	if r.LogLevel == nil || r.managedOCS.Spec.LogLevel == "" {
+		return
+	}
+	if changed, err := SetLogLevel(r.LogLevel, r.managedOCS.Spec.LogLevel); err != nil {
+		r.Log.Error(err, "Unable to set the log level of the ManagedOCS spec", "logLevel", r.managedOCS.Spec.LogLevel)
+	} else if changed {
+		r.Log.Info("Log level changed", "logLevel", r.managedOCS.Spec.LogLevel)
+	}
+}
+
+// getRequeueInterval returns the interval after which a successful reconcile should run again.
+// Components that are not ready are checked at the readiness check interval of the OperatorConfig,
+// otherwise the full reconcile interval of the ManagedOCS spec takes precedence over the
+// reconcile interval of the OperatorConfig.
+func (r *ManagedOCSReconciler) getRequeueInterval() time.Duration {
+	if !areComponentsReady(&r.managedOCS.Status) && r.operatorConfig.ReadinessCheckInterval != nil {
+		return r.operatorConfig.ReadinessCheckInterval.Duration
+	}
+	if minutes := r.managedOCS.Spec.FullReconcileIntervalMinutes; minutes != nil && *minutes > 0 {
+		return time.Duration(*minutes) * time.Minute
+	}
+	if r.operatorConfig.ReconcileInterval != nil {
+		return r.operatorConfig.ReconcileInterval.Duration
+	}
+	return defaultFullReconcileInterval
+}
+
+// checkStorageClusterPhaseTimeout reports a StorageCluster that stayed unavailable for longer
+// than the timeout configured in the OperatorConfig
+func (r *ManagedOCSReconciler) checkStorageClusterPhaseTimeout() {
+	timeout := r.operatorConfig.StorageClusterPhaseTimeout
+	if timeout == nil || timeout.Duration <= 0 ||
+		r.managedOCS.Status.Components.StorageCluster.State == v1.ComponentReady {
+		return
+	}
+
+	since := r.storageCluster.CreationTimestamp
+	condition := meta.FindStatusCondition(r.managedOCS.Status.Conditions, v1.ConditionStorageClusterAvailable)
+	if condition != nil {
+		if condition.Status == metav1.ConditionTrue {
+			return
+		}
+		since = condition.LastTransitionTime
+	}
+	if since.IsZero() || time.Since(since.Time) < timeout.Duration {
+		return
+	}
+
+	r.Log.Info("StorageCluster is unavailable for longer than the configured timeout",
+		"timeout", timeout.Duration, "phase", r.storageCluster.Status.Phase)
+	r.recordEvent(corev1.EventTypeWarning, eventReasonStorageClusterPhaseTimeout,
+		"StorageCluster %v has been unavailable for more than %v, current phase: %q",
+		r.storageCluster.Name, timeout.Duration, r.storageCluster.Status.Phase)
+}
@@ -0,0 +1,71 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Reconcile requeue intervals", func() {
+	var reconciler *ManagedOCSReconciler
+
+	BeforeEach(func() {
+		reconciler = &ManagedOCSReconciler{
+			managedOCS: &v1.ManagedOCS{},
+		}
+	})
+
+	When("there is no OperatorConfig", func() {
+		It("should requeue at the default full reconcile interval", func() {
+			Expect(reconciler.getRequeueInterval()).Should(Equal(defaultFullReconcileInterval))
+		})
+		It("should requeue at the full reconcile interval of the ManagedOCS spec", func() {
+			minutes := int32(15)
+			reconciler.managedOCS.Spec.FullReconcileIntervalMinutes = &minutes
+			Expect(reconciler.getRequeueInterval()).Should(Equal(15 * time.Minute))
+		})
+	})
+	When("the OperatorConfig sets both intervals", func() {
+		BeforeEach(func() {
+			reconciler.operatorConfig = v1.OperatorConfigSpec{
+				ReconcileInterval:      &metav1.Duration{Duration: time.Hour},
+				ReadinessCheckInterval: &metav1.Duration{Duration: 30 * time.Second},
+			}
+		})
+		It("should use the readiness check interval while components are not ready", func() {
+			Expect(reconciler.getRequeueInterval()).Should(Equal(30 * time.Second))
+		})
+		It("should use the reconcile interval once all components are ready", func() {
+			reconciler.managedOCS.Status.Components = v1.ComponentStatusMap{
+				StorageCluster: v1.ComponentStatus{State: v1.ComponentReady},
+				Prometheus:     v1.ComponentStatus{State: v1.ComponentReady},
+				Alertmanager:   v1.ComponentStatus{State: v1.ComponentReady},
+			}
+			Expect(reconciler.getRequeueInterval()).Should(Equal(time.Hour))
+
+			minutes := int32(15)
+			reconciler.managedOCS.Spec.FullReconcileIntervalMinutes = &minutes
+			Expect(reconciler.getRequeueInterval()).Should(Equal(15 * time.Minute))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("OSD count", func() {
+	newOSDPod := func(ready corev1.ConditionStatus) corev1.Pod {
+		pod := corev1.Pod{}
+		pod.Labels = map[string]string{osdLabelKey: osdLabelValue}
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+		return pod
+	}
+
+	It("should count the ready OSD pods", func() {
+		total, ready := getOSDPodCounts([]corev1.Pod{
+			newOSDPod(corev1.ConditionTrue),
+			newOSDPod(corev1.ConditionTrue),
+			newOSDPod(corev1.ConditionFalse),
+			{},
+		})
+		Expect(total).To(Equal(int32(4)))
+		Expect(ready).To(Equal(int32(2)))
+	})
+
+	It("should not count OSDs without pods", func() {
+		total, ready := getOSDPodCounts(nil)
+		Expect(total).To(BeZero())
+		Expect(ready).To(BeZero())
+	})
+
+	When("the StorageCluster is healthy", func() {
+		var managedOCS *v1.ManagedOCS
+		var storageCluster *ocsv1.StorageCluster
+
+		BeforeEach(func() {
+			managedOCS = &v1.ManagedOCS{}
+			storageCluster = &ocsv1.StorageCluster{}
+		})
+
+		It("should not be degraded while all the OSDs are ready", func() {
+			updateOSDDegradedCondition(managedOCS, storageCluster, 3, 3)
+			Expect(meta.IsStatusConditionFalse(managedOCS.Status.Conditions, v1.ConditionOSDDegraded)).To(BeTrue())
+		})
+
+		It("should be degraded while some OSDs are not ready", func() {
+			updateOSDDegradedCondition(managedOCS, storageCluster, 3, 2)
+			condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionOSDDegraded)
+			Expect(condition.Status).To(BeEquivalentTo(corev1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("OSDsNotReady"))
+			Expect(condition.Message).To(ContainSubstring("1 OSDs are not ready out of 3"))
+		})
+	})
+})
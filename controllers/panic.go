@@ -0,0 +1,61 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+)
+
+const (
+	// panicRetryInterval is the delay before a ManagedOCS resource whose reconcile panicked
+	// is reconciled again
+	panicRetryInterval = time.Minute
+
+	reconcilePanicReason = "ReconcilePanic"
+)
+
+// recoverReconcilePanic reports a panic recovered from a reconcile in the logs, with its stack
+// trace, and in an event and the ReconcileFailed condition of the ManagedOCS resource. It
+// returns the result delaying the next reconcile
+func (r *ManagedOCSReconciler) recoverReconcilePanic(ctx context.Context, recovered interface{}) ctrl.Result {
+	err := &deployererrors.ReconcileError{
+		Component: managedOCSName,
+		Reason:    reconcilePanicReason,
+		Retryable: true,
+		Err:       fmt.Errorf("%v", recovered),
+	}
+	r.Log.Error(err, "Recovered from a panic during reconcile", "stack", string(debug.Stack()))
+	reconcileErrors.WithLabelValues(reconcilePhasePanic).Inc()
+
+	if r.managedOCS == nil || r.managedOCS.UID == "" {
+		return ctrl.Result{RequeueAfter: panicRetryInterval}
+	}
+	r.recordEvent(corev1.EventTypeWarning, reconcilePanicReason, "Reconcile panicked: %v", recovered)
+	setReconcileFailedCondition(r.managedOCS, err)
+	if err := r.Client.Status().Update(ctx, r.managedOCS); err != nil {
+		r.Log.Error(err, "Unable to update the ManagedOCS status after a panic")
+	}
+	return ctrl.Result{RequeueAfter: panicRetryInterval}
+}
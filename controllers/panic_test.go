@@ -0,0 +1,91 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// panicClient panics on reads and records the status updates, other calls are not expected
+type panicClient struct {
+	client.Client
+	statusUpdates int
+}
+
+func (c *panicClient) Get(_ context.Context, _ client.ObjectKey, _ runtime.Object) error {
+	panic("unexpected get")
+}
+
+func (c *panicClient) List(_ context.Context, _ runtime.Object, _ ...client.ListOption) error {
+	panic("unexpected list")
+}
+
+func (c *panicClient) Status() client.StatusWriter {
+	return &panicStatusWriter{c}
+}
+
+type panicStatusWriter struct {
+	*panicClient
+}
+
+func (w *panicStatusWriter) Update(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+	w.statusUpdates++
+	return nil
+}
+
+var _ = Describe("Reconcile panic recovery", func() {
+	var reconciler *ManagedOCSReconciler
+	var panicking *panicClient
+
+	BeforeEach(func() {
+		panicking = &panicClient{}
+		reconciler = &ManagedOCSReconciler{
+			Client: panicking,
+			Log:    ctrl.Log.WithName("test"),
+		}
+	})
+
+	It("should recover from a panic and requeue the reconcile with a delay", func() {
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: managedOCSName, Namespace: "primary"}}
+		result, err := reconciler.Reconcile(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(panicRetryInterval))
+	})
+
+	It("should report the panic in the ManagedOCS status", func() {
+		reconciler.managedOCS = &v1.ManagedOCS{}
+		reconciler.managedOCS.UID = "managedocs-uid"
+		result := reconciler.recoverReconcilePanic(context.Background(), "nil pointer")
+		Expect(result.RequeueAfter).To(Equal(panicRetryInterval))
+
+		condition := meta.FindStatusCondition(reconciler.managedOCS.Status.Conditions, v1.ConditionReconcileFailed)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal(reconcilePanicReason))
+		Expect(condition.Message).To(ContainSubstring("nil pointer"))
+		Expect(panicking.statusUpdates).To(Equal(1))
+	})
+})
@@ -0,0 +1,60 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	eventReasonReconcilePaused  = "ReconcilePaused"
+	eventReasonReconcileResumed = "ReconcileResumed"
+)
+
+// checkSpecPaused reports whether the ManagedOCS is paused through spec.paused or
+// spec.maintenanceMode in the Paused condition, and returns whether it is paused
+func checkSpecPaused(managedOCS *v1.ManagedOCS) bool {
+	var reason, message string
+	switch {
+	case managedOCS.Spec.Paused:
+		reason, message = "SpecPaused", "Reconciliation is suspended until spec.paused is unset"
+	case managedOCS.Spec.MaintenanceMode == v1.MaintenanceModeDrainAndPause:
+		reason, message = v1.MaintenanceModeReason, "Reconciliation is drained and suspended until spec.maintenanceMode is unset"
+	default:
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionPaused)
+		return false
+	}
+
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionPaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
+
+// isSpecPaused checks whether the last reconcile was skipped because of spec.paused or
+// spec.maintenanceMode
+func isSpecPaused(managedOCS *v1.ManagedOCS) bool {
+	return meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionPaused)
+}
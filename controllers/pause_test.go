@@ -0,0 +1,108 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// pausedClient only finds a paused ManagedOCS resource and records the status updates, the
+// reconcile of a paused resource is not expected to write anything else
+type pausedClient struct {
+	client.Client
+	statusUpdates int
+}
+
+func (c *pausedClient) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	managedOCS, ok := obj.(*v1.ManagedOCS)
+	if !ok {
+		return errors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	managedOCS.UID = "managedocs-uid"
+	managedOCS.Generation = 2
+	managedOCS.Spec.Paused = true
+	return nil
+}
+
+func (c *pausedClient) Status() client.StatusWriter {
+	return &pausedStatusWriter{c}
+}
+
+type pausedStatusWriter struct {
+	*pausedClient
+}
+
+func (w *pausedStatusWriter) Update(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+	w.statusUpdates++
+	return nil
+}
+
+var _ = Describe("Spec pause", func() {
+	var managedOCS *v1.ManagedOCS
+
+	BeforeEach(func() {
+		managedOCS = &v1.ManagedOCS{}
+		managedOCS.Spec.Paused = true
+	})
+
+	It("should set the Paused condition while spec.paused is set", func() {
+		Expect(checkSpecPaused(managedOCS)).Should(BeTrue())
+		Expect(isSpecPaused(managedOCS)).Should(BeTrue())
+	})
+
+	It("should clear the Paused condition once spec.paused is unset", func() {
+		checkSpecPaused(managedOCS)
+		managedOCS.Spec.Paused = false
+		Expect(checkSpecPaused(managedOCS)).Should(BeFalse())
+		Expect(isSpecPaused(managedOCS)).Should(BeFalse())
+	})
+
+	It("should set the Paused condition while the DrainAndPause maintenance mode is set", func() {
+		managedOCS.Spec.Paused = false
+		managedOCS.Spec.MaintenanceMode = v1.MaintenanceModeDrainAndPause
+		Expect(checkSpecPaused(managedOCS)).Should(BeTrue())
+		Expect(isSpecPaused(managedOCS)).Should(BeTrue())
+		Expect(meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionPaused).Reason).Should(Equal(v1.MaintenanceModeReason))
+	})
+
+	It("should skip the reconcile without requeueing it", func() {
+		paused := &pausedClient{}
+		reconciler := &ManagedOCSReconciler{
+			Client: paused,
+			Log:    ctrl.Log.WithName("test"),
+		}
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: managedOCSName, Namespace: "primary"}}
+		result, err := reconciler.Reconcile(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(isSpecPaused(reconciler.managedOCS)).To(BeTrue())
+		Expect(reconciler.managedOCS.Status.ObservedGeneration).To(BeZero())
+		Expect(paused.statusUpdates).To(Equal(1))
+	})
+})
@@ -0,0 +1,119 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+const (
+	osdPDBName = "managed-ocs-osd-pdb"
+
+	// osdPDBMinAvailable keeps enough OSDs running during node drains to avoid under
+	// replicated data with the default replica of 3
+	osdPDBMinAvailable = 2
+)
+
+// PDBReconciler creates a PodDisruptionBudget for the OSD pods once the ManagedOCS resource is
+// ready, and deletes it when the ManagedOCS resource is being deleted. The PodDisruptionBudget
+// is kept while the ManagedOCS resource is not ready, as it matters most while OSDs are down
+type PDBReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups="policy",namespace=system,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
+
+// SetupWithManager creates and sets up a PDBReconciler to work with the provided manager
+func (r *PDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pdb").
+		For(&v1.ManagedOCS{}).
+		Owns(&policyv1beta1.PodDisruptionBudget{}).
+		Complete(r)
+}
+
+// Reconcile creates, updates or deletes the OSD PodDisruptionBudget of the ManagedOCS resource
+func (r *PDBReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	managedOCS := &v1.ManagedOCS{}
+	if err := r.Client.Get(ctx, req.NamespacedName, managedOCS); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(-1).Info("ManagedOCS resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	pdb.Name = osdPDBName
+	pdb.Namespace = req.Namespace
+
+	if !managedOCS.DeletionTimestamp.IsZero() {
+		if err := r.Client.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("unable to delete the OSD PodDisruptionBudget: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if managedOCS.Status.Phase != v1.PhaseReady {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := ctrl.CreateOrUpdate(ctx, r.Client, pdb, func() error {
+		if err := ctrl.SetControllerReference(managedOCS, pdb, r.Scheme); err != nil {
+			return err
+		}
+		pdb.Spec = getOSDPDBSpec()
+		return nil
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to reconcile the OSD PodDisruptionBudget: %w", err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("OSD PodDisruptionBudget reconciled", "result", result)
+	}
+	return ctrl.Result{}, nil
+}
+
+// getOSDPDBSpec returns the desired spec of the OSD PodDisruptionBudget, matching the pods by
+// the standard OSD pod label
+func getOSDPDBSpec() policyv1beta1.PodDisruptionBudgetSpec {
+	minAvailable := intstr.FromInt(osdPDBMinAvailable)
+	return policyv1beta1.PodDisruptionBudgetSpec{
+		MinAvailable: &minAvailable,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{osdLabelKey: osdLabelValue},
+		},
+	}
+}
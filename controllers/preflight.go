@@ -0,0 +1,206 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	// preflightRetryInterval is the interval at which failed preflight checks are retried.
+	// Nodes and storage classes are not watched, so the checks are polled.
+	preflightRetryInterval = time.Minute
+
+	preflightReasonSucceeded                = "PreflightSucceeded"
+	preflightReasonNamespaceNotFound        = "NamespaceNotFound"
+	preflightReasonStorageClusterCRDMissing = "StorageClusterCRDMissing"
+	preflightReasonInsufficientNodes        = "InsufficientNodes"
+	preflightReasonStorageClassNotFound     = "StorageClassNotFound"
+)
+
+// preflightCheck verifies a single prerequisite of the desired StorageCluster. A failed
+// check returns the reason and message reported on the PreflightFailed condition, an
+// error is returned only when the check itself could not be performed.
+type preflightCheck func(ctx context.Context, sc *ocsv1.StorageCluster) (reason string, message string, err error)
+
+// runPreflightChecks verifies that the cluster can host the desired StorageCluster and
+// reports the outcome in the PreflightFailed condition. It returns false if any of the
+// checks failed, in which case the StorageCluster must not be created or updated.
+func (r *ManagedOCSReconciler) runPreflightChecks(ctx context.Context) (bool, error) {
+	sc, err := r.getStorageClusterTemplate(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	checks := []preflightCheck{
+		r.checkNamespaceExists,
+		r.checkNamespaceMetadata,
+		r.checkStorageClusterCRDInstalled,
+		r.checkSchedulableNodes,
+		r.checkStorageClasses,
+	}
+	for _, check := range checks {
+		reason, message, err := check(ctx, sc)
+		if err != nil {
+			err = fmt.Errorf("failed to run preflight checks: %w", err)
+			return false, deployererrors.NewPreflightError(sc.Name, "PreflightCheckError", isRetriableError(err), err)
+		}
+		if reason != "" {
+			if !meta.IsStatusConditionTrue(r.managedOCS.Status.Conditions, v1.ConditionPreflightFailed) {
+				r.recordEvent(corev1.EventTypeWarning, eventReasonPreflightFailed, "%s", message)
+			}
+			r.setPreflightCondition(metav1.ConditionTrue, reason, message)
+			return false, nil
+		}
+	}
+
+	r.setPreflightCondition(metav1.ConditionFalse, preflightReasonSucceeded, "All preflight checks passed")
+	return true, nil
+}
+
+func (r *ManagedOCSReconciler) setPreflightCondition(status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&r.managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionPreflightFailed,
+		Status:             status,
+		ObservedGeneration: r.managedOCS.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func (r *ManagedOCSReconciler) checkNamespaceExists(ctx context.Context, _ *ocsv1.StorageCluster) (string, string, error) {
+	namespace := &corev1.Namespace{}
+	namespace.Name = r.namespace
+	if err := r.unrestrictedGet(ctx, namespace); err != nil {
+		if errors.IsNotFound(err) {
+			return preflightReasonNamespaceNotFound, fmt.Sprintf("Namespace %q does not exist", r.namespace), nil
+		}
+		return "", "", err
+	}
+	return "", "", nil
+}
+
+// checkNamespaceMetadata applies the labels Managed OpenShift requires on the namespace, and
+// fails when they are missing and cannot be applied
+func (r *ManagedOCSReconciler) checkNamespaceMetadata(ctx context.Context, _ *ocsv1.StorageCluster) (string, string, error) {
+	err := utils.ValidateNamespace(ctx, r.UnrestrictedClient, r.namespace)
+	if reconcileErr, ok := deployererrors.AsReconcileError(err); ok {
+		return reconcileErr.Reason, reconcileErr.Err.Error(), nil
+	}
+	return "", "", err
+}
+
+func (r *ManagedOCSReconciler) checkStorageClusterCRDInstalled(ctx context.Context, _ *ocsv1.StorageCluster) (string, string, error) {
+	scList := &ocsv1.StorageClusterList{}
+	if err := r.UnrestrictedClient.List(ctx, scList, client.InNamespace(r.namespace), client.Limit(1)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return preflightReasonStorageClusterCRDMissing, "The StorageCluster CRD is not installed, is the OCS operator running?", nil
+		}
+		return "", "", err
+	}
+	return "", "", nil
+}
+
+// checkSchedulableNodes verifies that there are at least as many ready and schedulable nodes
+// matching the StorageCluster label selector as the largest device set replica count
+func (r *ManagedOCSReconciler) checkSchedulableNodes(ctx context.Context, sc *ocsv1.StorageCluster) (string, string, error) {
+	var required int
+	for _, deviceSet := range sc.Spec.StorageDeviceSets {
+		if deviceSet.Replica > required {
+			required = deviceSet.Replica
+		}
+	}
+
+	selector := labels.Everything()
+	if sc.Spec.LabelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(sc.Spec.LabelSelector); err != nil {
+			return "", "", err
+		}
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.UnrestrictedClient.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", "", err
+	}
+
+	var schedulable int
+	for i := range nodeList.Items {
+		if isNodeSchedulable(&nodeList.Items[i]) {
+			schedulable++
+		}
+	}
+	if schedulable < required {
+		return preflightReasonInsufficientNodes,
+			fmt.Sprintf("Found %d schedulable storage nodes, at least %d are required", schedulable, required), nil
+	}
+	return "", "", nil
+}
+
+// checkStorageClasses verifies that the storage classes used to provision the StorageCluster
+// volumes exist. Capacity is provided on demand by the storage class provisioner.
+func (r *ManagedOCSReconciler) checkStorageClasses(ctx context.Context, sc *ocsv1.StorageCluster) (string, string, error) {
+	var storageClassNames []*string
+	if sc.Spec.MonPVCTemplate != nil {
+		storageClassNames = append(storageClassNames, sc.Spec.MonPVCTemplate.Spec.StorageClassName)
+	}
+	for _, deviceSet := range sc.Spec.StorageDeviceSets {
+		storageClassNames = append(storageClassNames, deviceSet.DataPVCTemplate.Spec.StorageClassName)
+	}
+
+	for _, name := range storageClassNames {
+		if name == nil || *name == "" {
+			continue
+		}
+		storageClass := &storagev1.StorageClass{}
+		storageClass.Name = *name
+		if err := r.unrestrictedGet(ctx, storageClass); err != nil {
+			if errors.IsNotFound(err) {
+				return preflightReasonStorageClassNotFound, fmt.Sprintf("StorageClass %q does not exist", *name), nil
+			}
+			return "", "", err
+		}
+	}
+	return "", "", nil
+}
+
+func isNodeSchedulable(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+const (
+	rateLimiterBaseDelayEnvVarName = "MANAGEDOCS_RATE_LIMITER_BASE_DELAY_MS"
+	rateLimiterMaxDelayEnvVarName  = "MANAGEDOCS_RATE_LIMITER_MAX_DELAY_S"
+
+	// The defaults are the ones of the controller-runtime rate limiter
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+)
+
+// newManagedOCSRateLimiter returns the rate limiter of the ManagedOCS controller queue. The
+// per item exponential failure delays can be set with environment variables, while the
+// overall rate of the queue is kept to the controller-runtime default
+func newManagedOCSRateLimiter() (ratelimiter.RateLimiter, error) {
+	baseDelay, err := readDelayEnvVar(rateLimiterBaseDelayEnvVarName, time.Millisecond, defaultRateLimiterBaseDelay)
+	if err != nil {
+		return nil, err
+	}
+	maxDelay, err := readDelayEnvVar(rateLimiterMaxDelayEnvVarName, time.Second, defaultRateLimiterMaxDelay)
+	if err != nil {
+		return nil, err
+	}
+	if baseDelay > maxDelay {
+		return nil, fmt.Errorf("%s must not be greater than %s", rateLimiterBaseDelayEnvVarName, rateLimiterMaxDelayEnvVarName)
+	}
+
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	), nil
+}
+
+// readDelayEnvVar reads a positive number of units from the named environment variable,
+// falling back to defaultDelay when it is not set
+func readDelayEnvVar(name string, unit time.Duration, defaultDelay time.Duration) (time.Duration, error) {
+	val, found := os.LookupEnv(name)
+	if !found || val == "" {
+		return defaultDelay, nil
+	}
+	count, err := strconv.Atoi(val)
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("%s environment variable must be a positive number, got %q", name, val)
+	}
+	return time.Duration(count) * unit, nil
+}
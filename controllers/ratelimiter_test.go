@@ -0,0 +1,58 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ManagedOCS rate limiter", func() {
+	AfterEach(func() {
+		Expect(os.Unsetenv(rateLimiterBaseDelayEnvVarName)).Should(Succeed())
+		Expect(os.Unsetenv(rateLimiterMaxDelayEnvVarName)).Should(Succeed())
+	})
+
+	It("should default to the controller-runtime delays", func() {
+		rateLimiter, err := newManagedOCSRateLimiter()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rateLimiter.When("item")).Should(Equal(defaultRateLimiterBaseDelay))
+	})
+
+	It("should use the delays set in the environment", func() {
+		Expect(os.Setenv(rateLimiterBaseDelayEnvVarName, "2000")).Should(Succeed())
+		Expect(os.Setenv(rateLimiterMaxDelayEnvVarName, "3")).Should(Succeed())
+		rateLimiter, err := newManagedOCSRateLimiter()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rateLimiter.When("item")).Should(Equal(2 * time.Second))
+		Expect(rateLimiter.When("item")).Should(Equal(3 * time.Second))
+	})
+
+	It("should reject invalid delays", func() {
+		Expect(os.Setenv(rateLimiterBaseDelayEnvVarName, "-1")).Should(Succeed())
+		_, err := newManagedOCSRateLimiter()
+		Expect(err).Should(HaveOccurred())
+
+		Expect(os.Setenv(rateLimiterBaseDelayEnvVarName, "5000")).Should(Succeed())
+		Expect(os.Setenv(rateLimiterMaxDelayEnvVarName, "1")).Should(Succeed())
+		_, err = newManagedOCSRateLimiter()
+		Expect(err).Should(HaveOccurred())
+	})
+})
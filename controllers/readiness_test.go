@@ -0,0 +1,57 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+var _ = Describe("StorageCluster readiness", func() {
+	newStorageCluster := func(phase string, available *corev1.ConditionStatus) *ocsv1.StorageCluster {
+		sc := &ocsv1.StorageCluster{}
+		sc.Status.Phase = phase
+		if available != nil {
+			sc.Status.Conditions = []conditionsv1.Condition{{
+				Type:   conditionsv1.ConditionAvailable,
+				Status: *available,
+			}}
+		}
+		return sc
+	}
+	conditionTrue := corev1.ConditionTrue
+	conditionFalse := corev1.ConditionFalse
+
+	When("the StorageCluster reports an Available condition", func() {
+		It("should follow the condition regardless of the phase", func() {
+			Expect(isStorageClusterAvailable(newStorageCluster(utils.PhaseProgressing, &conditionTrue))).To(BeTrue())
+			Expect(isStorageClusterAvailable(newStorageCluster(utils.PhaseReady, &conditionFalse))).To(BeFalse())
+		})
+	})
+	When("the StorageCluster did not report any conditions yet", func() {
+		It("should fall back to the phase", func() {
+			Expect(isStorageClusterAvailable(newStorageCluster(utils.PhaseReady, nil))).To(BeTrue())
+			Expect(isStorageClusterAvailable(newStorageCluster(utils.PhaseProgressing, nil))).To(BeFalse())
+			Expect(isStorageClusterAvailable(newStorageCluster("", nil))).To(BeFalse())
+		})
+	})
+})
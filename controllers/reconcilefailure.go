@@ -0,0 +1,54 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+// reconcileFailedReason is the reason of the ReconcileFailed condition for errors that are
+// not reconcile errors, e.g. API server failures
+const reconcileFailedReason = "ReconcileError"
+
+// setReconcileFailedCondition reports the outcome of a reconcile in the ReconcileFailed
+// condition and the error message of the status. The reason is the one of the reconcile error
+// when err is one
+func setReconcileFailedCondition(managedOCS *v1.ManagedOCS, err error) {
+	if err == nil {
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionReconcileFailed)
+		managedOCS.Status.ErrorMessage = ""
+		return
+	}
+	managedOCS.Status.ErrorMessage = err.Error()
+
+	reason := reconcileFailedReason
+	if reconcileErr, ok := deployererrors.AsReconcileError(err); ok {
+		reason = reconcileErr.Reason
+	}
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionReconcileFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+}
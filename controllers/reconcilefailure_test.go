@@ -0,0 +1,64 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	goerrors "errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+)
+
+var _ = Describe("ReconcileFailed condition", func() {
+	var managedOCS *v1.ManagedOCS
+
+	BeforeEach(func() {
+		managedOCS = &v1.ManagedOCS{}
+	})
+
+	It("should use the reason of reconcile errors", func() {
+		err := deployererrors.NewPreflightError("test-storagecluster", "PreflightCheckError", false, goerrors.New("boom"))
+		setReconcileFailedCondition(managedOCS, fmt.Errorf("reconcile failed: %w", err))
+
+		condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionReconcileFailed)
+		Expect(condition).ShouldNot(BeNil())
+		Expect(condition.Reason).Should(Equal("PreflightCheckError"))
+		Expect(condition.Message).Should(ContainSubstring("boom"))
+	})
+
+	It("should use a generic reason for other errors", func() {
+		setReconcileFailedCondition(managedOCS, goerrors.New("boom"))
+
+		condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionReconcileFailed)
+		Expect(condition).ShouldNot(BeNil())
+		Expect(condition.Reason).Should(Equal(reconcileFailedReason))
+	})
+
+	It("should remove the condition and the error message once a reconcile succeeds", func() {
+		setReconcileFailedCondition(managedOCS, goerrors.New("boom"))
+		Expect(managedOCS.Status.ErrorMessage).Should(Equal("boom"))
+		setReconcileFailedCondition(managedOCS, nil)
+
+		Expect(meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionReconcileFailed)).Should(BeNil())
+		Expect(managedOCS.Status.ErrorMessage).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,227 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/templates"
+)
+
+// secretClient serves a single secret, other calls are not expected
+type secretClient struct {
+	client.Client
+	secret *corev1.Secret
+}
+
+func (c *secretClient) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if secret, ok := obj.(*corev1.Secret); ok && key.Name == c.secret.Name && key.Namespace == c.secret.Namespace {
+		c.secret.DeepCopyInto(secret)
+		return nil
+	}
+	return errors.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+var _ = Describe("Reconcile strategy", func() {
+	When("the strategy is not recognized", func() {
+		It("should default to strict", func() {
+			Expect(getReconcileStrategy("")).To(Equal(v1.ReconcileStrategyStrict))
+			Expect(getReconcileStrategy("unknown")).To(Equal(v1.ReconcileStrategyStrict))
+		})
+	})
+	When("the strategy differs in case only", func() {
+		It("should be recognized", func() {
+			Expect(getReconcileStrategy("None")).To(Equal(v1.ReconcileStrategyNone))
+			Expect(getReconcileStrategy("FORCE")).To(Equal(v1.ReconcileStrategyForce))
+		})
+	})
+})
+
+var _ = Describe("Desired StorageCluster", func() {
+	var reconciler *ManagedOCSReconciler
+	var modified *ocsv1.StorageCluster
+
+	BeforeEach(func() {
+		testScheme := runtime.NewScheme()
+		Expect(v1.AddToScheme(testScheme)).To(Succeed())
+
+		addonParamSecret := &corev1.Secret{}
+		addonParamSecret.Name = "addon-params"
+		addonParamSecret.Namespace = "primary"
+		addonParamSecret.Data = map[string][]byte{storageClassSizeKey: []byte("1")}
+
+		reconciler = &ManagedOCSReconciler{
+			Client:               &secretClient{secret: addonParamSecret},
+			Log:                  ctrl.Log.WithName("test"),
+			Scheme:               testScheme,
+			AddonParamSecretName: addonParamSecret.Name,
+		}
+		reconciler.namespace = "primary"
+		reconciler.managedOCS = &v1.ManagedOCS{}
+		reconciler.managedOCS.Name = managedOCSName
+		reconciler.managedOCS.Namespace = "primary"
+		reconciler.managedOCS.UID = "managedocs-uid"
+
+		modified = &ocsv1.StorageCluster{Spec: *newTestStorageClusterSpec(nil)}
+		modified.Name = v1.DefaultStorageClusterName
+		modified.Namespace = "primary"
+		modified.Spec.Version = "modified-version"
+		reconciler.storageCluster = modified
+	})
+
+	When("the reconcile strategy is strict", func() {
+		It("should overwrite the StorageCluster spec", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec.Version).To(Equal(templates.StorageClusterTemplate.Spec.Version))
+		})
+	})
+	When("the reconcile strategy is force and a template ConfigMap is referenced", func() {
+		It("should use the built-in template", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyForce
+			reconciler.storageClusterTemplateRef = &corev1.LocalObjectReference{Name: "custom-template"}
+			desired, err := reconciler.getStorageClusterTemplate(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(desired.Spec).To(Equal(templates.StorageClusterTemplate.Spec))
+		})
+	})
+	When("the reconcile strategy is none", func() {
+		It("should leave the StorageCluster spec unchanged", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyNone
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec).To(Equal(modified.Spec))
+		})
+	})
+	When("a ceph cluster spec is set in the ManagedOCS spec", func() {
+		It("should merge it on top of the StorageCluster spec", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			reconciler.managedOCS.Spec.CephClusterSpec = &runtime.RawExtension{
+				Raw: []byte(`{"externalStorage":{"enable":true},"version":"passthrough-version"}`),
+			}
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec.ExternalStorage.Enable).To(BeTrue())
+			Expect(sc.Spec.Version).To(Equal("passthrough-version"))
+			Expect(sc.Spec.StorageDeviceSets).To(HaveLen(len(templates.StorageClusterTemplate.Spec.StorageDeviceSets)))
+		})
+	})
+	When("the template version differs from the applied one", func() {
+		BeforeEach(func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			reconciler.managedOCS.Status.AppliedTemplateVersion = "0.9.0"
+			modified.CreationTimestamp = metav1.Now()
+		})
+
+		It("should leave the StorageCluster spec unchanged unless the upgrade is allowed", func() {
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec).To(Equal(modified.Spec))
+			Expect(reconciler.storageClusterTemplateVersion).To(Equal("0.9.0"))
+		})
+		It("should apply the template once the upgrade is allowed", func() {
+			reconciler.managedOCS.Spec.AllowTemplateUpgrade = true
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec.Version).To(Equal(templates.StorageClusterTemplate.Spec.Version))
+			Expect(reconciler.storageClusterTemplateVersion).To(Equal(templates.StorageClusterTemplateVersion))
+		})
+	})
+	When("Ceph is recovering", func() {
+		It("should leave the StorageCluster spec unchanged and defer the update", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			conditionsv1.SetStatusCondition(&modified.Status.Conditions, conditionsv1.Condition{
+				Type:   conditionStorageClusterRecovering,
+				Status: corev1.ConditionTrue,
+				Reason: "Backfilling",
+			})
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec).To(Equal(modified.Spec))
+			Expect(reconciler.deferredStorageClusters).To(ConsistOf(modified.Name))
+		})
+	})
+	When("storage cluster annotations are set in the ManagedOCS spec", func() {
+		BeforeEach(func() {
+			reconciler.managedOCS.Spec.StorageClusterAnnotations = map[string]string{
+				"cluster.ocs.openshift.io/local-devices": "true",
+			}
+			modified.Annotations = map[string]string{
+				"cluster.ocs.openshift.io/local-devices": "false",
+				"example.com/other":                      "kept",
+			}
+		})
+
+		It("should override the StorageCluster annotations under the strict strategy", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Annotations).To(Equal(map[string]string{
+				"cluster.ocs.openshift.io/local-devices": "true",
+				"example.com/other":                      "kept",
+			}))
+		})
+		It("should leave the StorageCluster annotations unchanged under the none strategy", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyNone
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Annotations).To(Equal(modified.Annotations))
+		})
+	})
+	When("storage cluster labels are set in the ManagedOCS spec", func() {
+		It("should override the StorageCluster labels", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			reconciler.managedOCS.Spec.StorageClusterLabels = map[string]string{"cost-center": "storage"}
+			modified.Labels = map[string]string{"cost-center": "unknown", "team": "kept"}
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Labels).To(Equal(map[string]string{"cost-center": "storage", "team": "kept"}))
+		})
+	})
+	When("an external Ceph secret is referenced in the ManagedOCS spec", func() {
+		It("should enable external storage", func() {
+			reconciler.reconcileStrategy = v1.ReconcileStrategyStrict
+			reconciler.managedOCS.Spec.ExternalCephSecretRef = &corev1.LocalObjectReference{Name: "external-ceph"}
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(sc.Spec.ExternalStorage.Enable).To(BeTrue())
+		})
+	})
+	It("should set the ManagedOCS resource as the controller of the StorageCluster", func() {
+		for _, strategy := range []v1.ReconcileStrategy{v1.ReconcileStrategyStrict, v1.ReconcileStrategyNone} {
+			reconciler.reconcileStrategy = strategy
+			sc := modified.DeepCopy()
+			Expect(reconciler.setDesiredStorageCluster(context.Background(), sc)).To(Succeed())
+			Expect(metav1.IsControlledBy(sc, reconciler.managedOCS)).To(BeTrue())
+			Expect(isOwnedByManagedOCS(sc)).To(BeTrue())
+		}
+	})
+})
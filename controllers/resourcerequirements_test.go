@@ -0,0 +1,61 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("Resource requirements overrides", func() {
+	newResources := func(cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+		}
+	}
+
+	It("should apply the osd entry to all the storage device sets", func() {
+		spec := &ocsv1.StorageClusterSpec{
+			StorageDeviceSets: []ocsv1.StorageDeviceSet{{Name: "a"}, {Name: "b"}},
+		}
+		applyResourceRequirements(spec, map[string]corev1.ResourceRequirements{"osd": newResources("2")})
+		for _, deviceSet := range spec.StorageDeviceSets {
+			Expect(deviceSet.Resources).To(Equal(newResources("2")))
+		}
+		Expect(spec.Resources).ToNot(HaveKey("osd"))
+	})
+	It("should replace the StorageCluster resources of the other entries", func() {
+		spec := &ocsv1.StorageClusterSpec{
+			Resources: map[string]corev1.ResourceRequirements{
+				"mds": newResources("1"),
+				"mon": newResources("1"),
+			},
+		}
+		applyResourceRequirements(spec, map[string]corev1.ResourceRequirements{
+			"mds": newResources("3"),
+			"rgw": newResources("2"),
+		})
+		Expect(spec.Resources).To(Equal(map[string]corev1.ResourceRequirements{
+			"mds": newResources("3"),
+			"mon": newResources("1"),
+			"rgw": newResources("2"),
+		}))
+	})
+})
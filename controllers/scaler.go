@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+	"github.com/openshift/ocs-osd-deployer/utils/sizing"
+)
+
+const (
+	// scalingRetryInterval is the interval at which the storage nodes are listed again while a
+	// scale-up waits for them, as nodes are not watched
+	scalingRetryInterval = time.Minute
+
+	eventReasonScalingStarted = "ScalingStarted"
+)
+
+// scaleStorageDeviceSets runs the scale-up state machine of the primary StorageCluster,
+// recorded in the ManagedOCS scaling phase, and returns the storage device set count to apply.
+//
+// An increase of the requested count moves to the Pending phase, keeping the current count,
+// until the storage nodes can host the requested count. The requested count is then applied
+// and the phase moves to Scaling, until the StorageCluster is ready again and the phase
+// moves to Ready. Decreases are rejected by the validating webhook.
+func (r *ManagedOCSReconciler) scaleStorageDeviceSets(ctx context.Context, current *ocsv1.StorageCluster, requested int) (int, error) {
+	// New StorageClusters are created with the requested count, and a dry run shows the
+	// requested count without going through the phases
+	if current.UID == "" || !r.isPrimaryStorageCluster() || r.isDryRun() {
+		return requested, nil
+	}
+
+	currentCount := getStorageDeviceSetCount(&current.Spec)
+	if requested <= currentCount {
+		if r.managedOCS.Status.ScalingPhase != v1.ScalingPhaseReady && current.Status.Phase == utils.PhaseReady {
+			r.managedOCS.Status.ScalingPhase = v1.ScalingPhaseReady
+		}
+		return requested, nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.UnrestrictedClient.List(ctx, nodeList, client.HasLabels{sizing.StorageNodeLabelKey}); err != nil {
+		return 0, fmt.Errorf("unable to list storage nodes: %w", err)
+	}
+	if capacity, err := sizing.Calculate(nodeList.Items, autoSizingDeviceClass); err != nil || capacity < requested {
+		r.Log.Info("Waiting for storage nodes to scale up storage device sets",
+			"currentCount", currentCount, "requestedCount", requested, "capacity", capacity, "reason", err)
+		r.managedOCS.Status.ScalingPhase = v1.ScalingPhasePending
+		return currentCount, nil
+	}
+
+	r.recordEvent(corev1.EventTypeNormal, eventReasonScalingStarted,
+		"Scaling storage device sets from %d to %d", currentCount, requested)
+	r.managedOCS.Status.ScalingPhase = v1.ScalingPhaseScaling
+	return requested, nil
+}
+
+// getStorageDeviceSetCount returns the highest count of the storage device sets
+func getStorageDeviceSetCount(spec *ocsv1.StorageClusterSpec) int {
+	count := 0
+	for i := range spec.StorageDeviceSets {
+		if spec.StorageDeviceSets[i].Count > count {
+			count = spec.StorageDeviceSets[i].Count
+		}
+	}
+	return count
+}
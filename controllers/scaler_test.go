@@ -0,0 +1,110 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+	"github.com/openshift/ocs-osd-deployer/utils/sizing"
+)
+
+// nodeClient lists a fixed set of nodes, other calls are not expected
+type nodeClient struct {
+	client.Client
+	nodes []corev1.Node
+}
+
+func (c *nodeClient) List(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+	list.(*corev1.NodeList).Items = c.nodes
+	return nil
+}
+
+var _ = Describe("StorageCluster scaler", func() {
+	var reconciler *ManagedOCSReconciler
+	var nodes *nodeClient
+	var current *ocsv1.StorageCluster
+
+	newStorageNode := func(name string, devices string) corev1.Node {
+		node := corev1.Node{}
+		node.Name = name
+		node.Labels = map[string]string{sizing.StorageNodeLabelKey: ""}
+		node.Annotations = map[string]string{sizing.DeviceCountAnnotationPrefix + autoSizingDeviceClass: devices}
+		return node
+	}
+
+	BeforeEach(func() {
+		nodes = &nodeClient{nodes: []corev1.Node{
+			newStorageNode("node-a", "1"),
+			newStorageNode("node-b", "1"),
+			newStorageNode("node-c", "1"),
+		}}
+		reconciler = &ManagedOCSReconciler{
+			UnrestrictedClient: nodes,
+			Log:                ctrl.Log.WithName("test"),
+		}
+		reconciler.managedOCS = &v1.ManagedOCS{}
+		reconciler.storageCluster = &ocsv1.StorageCluster{}
+		reconciler.storageCluster.Name = getStorageClusterName(reconciler.managedOCS)
+
+		current = reconciler.storageCluster.DeepCopy()
+		current.UID = "storagecluster-uid"
+		current.Status.Phase = utils.PhaseReady
+		current.Spec.StorageDeviceSets = []ocsv1.StorageDeviceSet{{Name: "default", Count: 1}}
+	})
+
+	When("the StorageCluster does not exist yet", func() {
+		It("should apply the requested count", func() {
+			current.UID = ""
+			Expect(reconciler.scaleStorageDeviceSets(context.Background(), current, 4)).Should(Equal(4))
+			Expect(reconciler.managedOCS.Status.ScalingPhase).Should(BeEmpty())
+		})
+	})
+	When("the storage nodes cannot host the requested count", func() {
+		It("should keep the current count until they can", func() {
+			Expect(reconciler.scaleStorageDeviceSets(context.Background(), current, 2)).Should(Equal(1))
+			Expect(reconciler.managedOCS.Status.ScalingPhase).Should(Equal(v1.ScalingPhasePending))
+
+			nodes.nodes = append(nodes.nodes,
+				newStorageNode("node-d", "1"), newStorageNode("node-e", "1"), newStorageNode("node-f", "1"))
+			Expect(reconciler.scaleStorageDeviceSets(context.Background(), current, 2)).Should(Equal(2))
+			Expect(reconciler.managedOCS.Status.ScalingPhase).Should(Equal(v1.ScalingPhaseScaling))
+		})
+	})
+	When("the requested count is applied", func() {
+		It("should be ready once the StorageCluster is ready", func() {
+			reconciler.managedOCS.Status.ScalingPhase = v1.ScalingPhaseScaling
+			current.Spec.StorageDeviceSets[0].Count = 2
+			current.Status.Phase = utils.PhaseProgressing
+			Expect(reconciler.scaleStorageDeviceSets(context.Background(), current, 2)).Should(Equal(2))
+			Expect(reconciler.managedOCS.Status.ScalingPhase).Should(Equal(v1.ScalingPhaseScaling))
+
+			current.Status.Phase = utils.PhaseReady
+			Expect(reconciler.scaleStorageDeviceSets(context.Background(), current, 2)).Should(Equal(2))
+			Expect(reconciler.managedOCS.Status.ScalingPhase).Should(Equal(v1.ScalingPhaseReady))
+		})
+	})
+})
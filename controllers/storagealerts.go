@@ -0,0 +1,99 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift/ocs-osd-deployer/templates"
+)
+
+// reconcileStorageAlertsPrometheusRule creates the PrometheusRule holding the storage alerting
+// rules when alerting is enabled in the ManagedOCS spec, and deletes it once it is disabled. It
+// is labeled for the Prometheus of the deployer by reconcileMonitoringResources
+func (r *ManagedOCSReconciler) reconcileStorageAlertsPrometheusRule(ctx context.Context) error {
+	r.Log.Info("Reconciling storage alerts Prometheus Rule")
+
+	alertingSpec := r.managedOCS.Spec.AlertingSpec
+	if alertingSpec == nil || !alertingSpec.Enabled {
+		if err := r.delete(ctx, r.storageAlertsRule); err != nil {
+			return fmt.Errorf("Failed to delete storage alerts PrometheusRule: %w", err)
+		}
+		return nil
+	}
+
+	ruleGroups := templates.StorageAlertsPrometheusRuleTemplate.DeepCopy().Spec.Groups
+	for _, configMapName := range alertingSpec.CustomRules {
+		customRuleGroups, err := r.getCustomRuleGroups(ctx, configMapName)
+		if err != nil {
+			return err
+		}
+		ruleGroups = append(ruleGroups, customRuleGroups...)
+	}
+
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, r.storageAlertsRule, func() error {
+		if err := r.own(r.storageAlertsRule); err != nil {
+			return err
+		}
+		r.storageAlertsRule.Spec = promv1.PrometheusRuleSpec{Groups: ruleGroups}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to update storage alerts PrometheusRule: %w", err)
+	}
+	return nil
+}
+
+// getCustomRuleGroups returns the rule groups of the PrometheusRule held by the custom alerting
+// rules ConfigMap
+func (r *ManagedOCSReconciler) getCustomRuleGroups(ctx context.Context, configMapName string) ([]promv1.RuleGroup, error) {
+	configMap := &corev1.ConfigMap{}
+	configMap.Name = configMapName
+	configMap.Namespace = r.namespace
+	if err := r.get(ctx, configMap); err != nil {
+		return nil, fmt.Errorf("Failed to get custom alerting rules ConfigMap %v: %w", configMapName, err)
+	}
+	data, ok := configMap.Data[alertingRulesKey]
+	if !ok {
+		return nil, fmt.Errorf("Custom alerting rules ConfigMap %v does not contain a %v entry", configMapName, alertingRulesKey)
+	}
+	return parseCustomRuleGroups(configMapName, data)
+}
+
+// parseCustomRuleGroups returns the rule groups of the PrometheusRule YAML data read from the
+// custom alerting rules ConfigMap
+func parseCustomRuleGroups(configMapName, data string) ([]promv1.RuleGroup, error) {
+	jsonData, err := utilyaml.ToJSON([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid custom alerting rules in ConfigMap %v: %w", configMapName, err)
+	}
+	rule := promv1.PrometheusRule{}
+	if err := json.Unmarshal(jsonData, &rule); err != nil {
+		return nil, fmt.Errorf("Invalid custom alerting rules in ConfigMap %v: %w", configMapName, err)
+	}
+	if len(rule.Spec.Groups) == 0 {
+		return nil, fmt.Errorf("Custom alerting rules ConfigMap %v does not define any rule group", configMapName)
+	}
+	return rule.Spec.Groups, nil
+}
@@ -0,0 +1,56 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Custom alerting rules", func() {
+	It("should return the rule groups of the PrometheusRule", func() {
+		ruleGroups, err := parseCustomRuleGroups("custom-rules", `
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: custom
+spec:
+  groups:
+  - name: custom.rules
+    rules:
+    - alert: CustomAlert
+      expr: vector(1)
+      for: 1m
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleGroups).To(HaveLen(1))
+		Expect(ruleGroups[0].Name).To(Equal("custom.rules"))
+		Expect(ruleGroups[0].Rules).To(HaveLen(1))
+		Expect(ruleGroups[0].Rules[0].Alert).To(Equal("CustomAlert"))
+		Expect(ruleGroups[0].Rules[0].Expr.String()).To(Equal("vector(1)"))
+	})
+
+	It("should fail on an invalid PrometheusRule", func() {
+		_, err := parseCustomRuleGroups("custom-rules", "spec: [")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail on a PrometheusRule without rule groups", func() {
+		_, err := parseCustomRuleGroups("custom-rules", "kind: PrometheusRule")
+		Expect(err).To(MatchError(ContainSubstring("does not define any rule group")))
+	})
+})
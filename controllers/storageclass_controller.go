@@ -0,0 +1,226 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	// defaultStorageClassTimeout is used when the OperatorConfig does not set a StorageClass timeout
+	defaultStorageClassTimeout = 10 * time.Minute
+
+	eventReasonStorageClassMissing = "StorageClassMissing"
+)
+
+// StorageClassReconciler checks that the StorageClasses provisioned by the managed StorageCluster
+// exist once it is available, and reflects it in the status of the ManagedOCS resource
+type StorageClassReconciler struct {
+	Client    client.Client
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Namespace string
+
+	recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups="storage.k8s.io",resources=storageclasses,verbs=get;list;watch
+
+func (r *StorageClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("storageclass-controller")
+
+	// StorageClasses are cluster scoped, they are mapped to the ManagedOCS of the watched namespace
+	enqueueManagedOCSRequest := handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(
+			func(obj handler.MapObject) []reconcile.Request {
+				return []reconcile.Request{{
+					NamespacedName: types.NamespacedName{
+						Name:      managedOCSName,
+						Namespace: r.Namespace,
+					},
+				}}
+			},
+		),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("storageclass").
+		For(&ocsv1.StorageCluster{}).
+		Watches(&source.Kind{Type: &storagev1.StorageClass{}}, &enqueueManagedOCSRequest).
+		Complete(r)
+}
+
+// Reconcile updates the StorageClass conditions of the ManagedOCS resource. Only the namespace of
+// the request is used, the StorageCluster name is read from the ManagedOCS spec
+func (r *StorageClassReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	managedOCS := &v1.ManagedOCS{}
+	managedOCSKey := types.NamespacedName{Name: managedOCSName, Namespace: req.Namespace}
+	if err := r.Client.Get(ctx, managedOCSKey, managedOCS); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(-1).Info("ManagedOCS resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	status := managedOCS.Status.DeepCopy()
+	result := ctrl.Result{}
+	storageCluster := &ocsv1.StorageCluster{}
+	storageClusterKey := types.NamespacedName{Name: getStorageClusterName(managedOCS), Namespace: req.Namespace}
+	if err := r.Client.Get(ctx, storageClusterKey, storageCluster); err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	} else if err != nil || !isStorageClusterAvailable(storageCluster) {
+		// StorageClasses are only expected once the StorageCluster is available
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionStorageClassesReady)
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionStorageClassMissing)
+	} else {
+		missing, err := r.findMissingStorageClasses(ctx, storageCluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		timeout, err := r.getStorageClassTimeout(ctx, req.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		remaining := timeout - time.Since(getStorageClusterAvailableTime(storageCluster))
+		wasMissing := meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionStorageClassMissing)
+		updateStorageClassConditions(managedOCS, missing, remaining <= 0)
+		if len(missing) > 0 && remaining > 0 {
+			result.RequeueAfter = remaining
+		}
+		if !wasMissing && meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionStorageClassMissing) {
+			r.recorder.Eventf(managedOCS, corev1.EventTypeWarning, eventReasonStorageClassMissing,
+				"StorageClasses %v are missing %v after the StorageCluster became available",
+				strings.Join(missing, ", "), timeout)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(status, &managedOCS.Status) {
+		return result, nil
+	}
+	log.Info("Updating StorageClass conditions of ManagedOCS")
+	return result, r.Client.Status().Update(ctx, managedOCS)
+}
+
+// getExpectedStorageClassNames returns the names of the StorageClasses the OCS operator creates
+// for the StorageCluster. The object store StorageClass is not created on cloud platforms
+func getExpectedStorageClassNames(storageCluster *ocsv1.StorageCluster) []string {
+	return []string{
+		fmt.Sprintf("%s-ceph-rbd", storageCluster.Name),
+		fmt.Sprintf("%s-cephfs", storageCluster.Name),
+	}
+}
+
+func (r *StorageClassReconciler) findMissingStorageClasses(ctx context.Context, storageCluster *ocsv1.StorageCluster) ([]string, error) {
+	var missing []string
+	for _, name := range getExpectedStorageClassNames(storageCluster) {
+		storageClass := &storagev1.StorageClass{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, storageClass); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to get StorageClass %v: %w", name, err)
+			}
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// getStorageClassTimeout reads the StorageClass timeout from the OperatorConfig of the namespace
+func (r *StorageClassReconciler) getStorageClassTimeout(ctx context.Context, namespace string) (time.Duration, error) {
+	operatorConfig := &v1.OperatorConfig{}
+	operatorConfigKey := types.NamespacedName{Name: operatorConfigName, Namespace: namespace}
+	if err := r.Client.Get(ctx, operatorConfigKey, operatorConfig); err != nil {
+		if errors.IsNotFound(err) {
+			return defaultStorageClassTimeout, nil
+		}
+		return 0, err
+	}
+	if timeout := operatorConfig.Spec.StorageClassTimeout; timeout != nil && timeout.Duration > 0 {
+		return timeout.Duration, nil
+	}
+	return defaultStorageClassTimeout, nil
+}
+
+// getStorageClusterAvailableTime returns the time the StorageCluster became available, falling
+// back to its creation time for StorageClusters that only report a phase
+func getStorageClusterAvailableTime(storageCluster *ocsv1.StorageCluster) time.Time {
+	condition := conditionsv1.FindStatusCondition(storageCluster.Status.Conditions, conditionsv1.ConditionAvailable)
+	if condition != nil && !condition.LastTransitionTime.IsZero() {
+		return condition.LastTransitionTime.Time
+	}
+	return storageCluster.CreationTimestamp.Time
+}
+
+// updateStorageClassConditions sets the StorageClass conditions from the missing StorageClasses.
+// Missing StorageClasses are only reported by the StorageClassMissing condition once timedOut is set
+func updateStorageClassConditions(managedOCS *v1.ManagedOCS, missing []string, timedOut bool) {
+	ready := metav1.Condition{
+		Type:               v1.ConditionStorageClassesReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             "StorageClassesFound",
+		Message:            "The StorageClasses of the StorageCluster exist",
+	}
+	missingCondition := metav1.Condition{
+		Type:               v1.ConditionStorageClassMissing,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             "StorageClassesFound",
+		Message:            "The StorageClasses of the StorageCluster exist",
+	}
+	if len(missing) > 0 {
+		message := fmt.Sprintf("StorageClasses %v do not exist", strings.Join(missing, ", "))
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "StorageClassesPending"
+		ready.Message = message
+		missingCondition.Reason = "StorageClassesPending"
+		missingCondition.Message = message
+		if timedOut {
+			missingCondition.Status = metav1.ConditionTrue
+			missingCondition.Reason = "StorageClassTimeout"
+		}
+	}
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, ready)
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, missingCondition)
+}
@@ -0,0 +1,74 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("StorageClass conditions", func() {
+	When("all StorageClasses exist", func() {
+		It("should report them as ready", func() {
+			managedOCS := &v1.ManagedOCS{}
+			updateStorageClassConditions(managedOCS, nil, true)
+			Expect(meta.IsStatusConditionTrue(managedOCS.Status.Conditions, v1.ConditionStorageClassesReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(managedOCS.Status.Conditions, v1.ConditionStorageClassMissing)).To(BeTrue())
+		})
+	})
+	When("StorageClasses are missing", func() {
+		It("should only report them as missing once the timeout elapsed", func() {
+			managedOCS := &v1.ManagedOCS{}
+			updateStorageClassConditions(managedOCS, []string{"test-cephfs"}, false)
+			Expect(meta.IsStatusConditionFalse(managedOCS.Status.Conditions, v1.ConditionStorageClassesReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(managedOCS.Status.Conditions, v1.ConditionStorageClassMissing)).To(BeTrue())
+
+			updateStorageClassConditions(managedOCS, []string{"test-cephfs"}, true)
+			condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionStorageClassMissing)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("test-cephfs"))
+		})
+	})
+})
+
+var _ = Describe("StorageClass expectations", func() {
+	It("should expect the rbd and cephfs StorageClasses of the StorageCluster", func() {
+		sc := &ocsv1.StorageCluster{}
+		sc.Name = "test"
+		Expect(getExpectedStorageClassNames(sc)).To(ConsistOf("test-ceph-rbd", "test-cephfs"))
+	})
+	It("should measure the timeout from the Available condition transition", func() {
+		sc := &ocsv1.StorageCluster{}
+		sc.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(getStorageClusterAvailableTime(sc)).To(Equal(sc.CreationTimestamp.Time))
+
+		available := metav1.NewTime(time.Now().Add(-time.Minute))
+		sc.Status.Conditions = []conditionsv1.Condition{{
+			Type:               conditionsv1.ConditionAvailable,
+			LastTransitionTime: available,
+		}}
+		Expect(getStorageClusterAvailableTime(sc)).To(Equal(available.Time))
+	})
+})
@@ -0,0 +1,308 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+// defaultStorageDeviceSetReplica is the replica the ocs-operator uses for storage device sets
+// that do not set one
+const defaultStorageDeviceSetReplica = 3
+
+// storageClusterConditionTypes lists the StorageCluster condition types that are mirrored
+// into the ManagedOCS status, together with the condition type they are mirrored as
+var storageClusterConditionTypes = []struct {
+	source conditionsv1.ConditionType
+	target string
+}{
+	{conditionsv1.ConditionAvailable, v1.ConditionStorageClusterAvailable},
+	{conditionsv1.ConditionProgressing, v1.ConditionStorageClusterProgressing},
+	{conditionsv1.ConditionDegraded, v1.ConditionStorageClusterDegraded},
+}
+
+// StorageClusterWatcher tracks the lifecycle of the managed StorageCluster and reflects its
+// readiness in the status of the ManagedOCS resource in the same namespace
+type StorageClusterWatcher struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	recorder record.EventRecorder
+}
+
+func (r *StorageClusterWatcher) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("storagecluster-watcher")
+
+	osdPodPredicates := builder.WithPredicates(
+		predicate.NewPredicateFuncs(
+			func(meta metav1.Object, _ runtime.Object) bool {
+				return meta.GetLabels()[osdLabelKey] == osdLabelValue
+			},
+		),
+	)
+	// OSD pod readiness is reported in the OSDDegraded condition message
+	enqueueManagedOCSRequest := handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(
+			func(obj handler.MapObject) []reconcile.Request {
+				return []reconcile.Request{{
+					NamespacedName: types.NamespacedName{
+						Name:      managedOCSName,
+						Namespace: obj.Meta.GetNamespace(),
+					},
+				}}
+			},
+		),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("storagecluster-watcher").
+		For(&ocsv1.StorageCluster{}).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, &enqueueManagedOCSRequest, osdPodPredicates).
+		Complete(r)
+}
+
+// Reconcile updates the StorageCluster component status and conditions of the ManagedOCS resource.
+// Only the namespace of the request is used, the StorageCluster name is read from the ManagedOCS spec
+func (r *StorageClusterWatcher) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("req.Namespace", req.Namespace, "req.Name", req.Name)
+
+	managedOCS := &v1.ManagedOCS{}
+	managedOCSKey := types.NamespacedName{Name: managedOCSName, Namespace: req.Namespace}
+	if err := r.Client.Get(ctx, managedOCSKey, managedOCS); err != nil {
+		if errors.IsNotFound(err) {
+			log.V(-1).Info("ManagedOCS resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	status := managedOCS.Status.DeepCopy()
+	result := ctrl.Result{}
+	// Errors getting the StorageCluster are returned once the Unknown state is reported, so the
+	// request is retried with a backoff
+	var storageClusterErr error
+	storageCluster := &ocsv1.StorageCluster{}
+	storageClusterKey := types.NamespacedName{Name: getStorageClusterName(managedOCS), Namespace: req.Namespace}
+	if err := r.Client.Get(ctx, storageClusterKey, storageCluster); err == nil {
+		updateStorageClusterConditions(managedOCS, storageCluster)
+		totalOSDs, readyOSDs, err := r.countOSDPods(ctx, req.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		managedOCS.Status.TotalOSDCount = totalOSDs
+		managedOCS.Status.ReadyOSDCount = readyOSDs
+		updateOSDDegradedCondition(managedOCS, storageCluster, totalOSDs, readyOSDs)
+		if isStorageClusterAvailable(storageCluster) {
+			managedOCS.Status.Components.StorageCluster.State = v1.ComponentReady
+		} else {
+			managedOCS.Status.Components.StorageCluster.State = v1.ComponentPending
+		}
+		managedOCS.Status.Phase = getManagedOCSPhase(storageCluster)
+		managedOCS.Status.TotalCapacityBytes = getStorageClusterCapacityBytes(storageCluster)
+		usedCapacityBytes, err := getUsedCapacityBytes(ctx, r.Client, storageCluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		managedOCS.Status.UsedCapacityBytes = usedCapacityBytes
+		result.RequeueAfter = capacityRefreshInterval
+	} else if errors.IsNotFound(err) {
+		removeStorageClusterConditions(managedOCS)
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, v1.ConditionOSDDegraded)
+		managedOCS.Status.Components.StorageCluster.State = v1.ComponentNotFound
+		managedOCS.Status.Phase = v1.PhaseInitializing
+		managedOCS.Status.TotalCapacityBytes = 0
+		managedOCS.Status.UsedCapacityBytes = 0
+		managedOCS.Status.TotalOSDCount = 0
+		managedOCS.Status.ReadyOSDCount = 0
+	} else {
+		log.V(-1).Info("error getting StorageCluster, setting compoment status to Unknown")
+		managedOCS.Status.Components.StorageCluster.State = v1.ComponentUnknown
+		managedOCS.Status.Phase = v1.PhaseUnknown
+		storageClusterErr = fmt.Errorf("unable to get StorageCluster %v: %w", storageClusterKey.Name, err)
+	}
+	if updateCapacityWarningCondition(managedOCS) && r.recorder != nil {
+		condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionCapacityWarning)
+		r.recorder.Event(managedOCS, corev1.EventTypeWarning, eventReasonCapacityCritical, condition.Message)
+	}
+
+	if equality.Semantic.DeepEqual(status, &managedOCS.Status) {
+		return result, storageClusterErr
+	}
+	log.Info("Updating StorageCluster status of ManagedOCS",
+		"state", managedOCS.Status.Components.StorageCluster.State,
+		"phase", managedOCS.Status.Phase)
+	if err := r.Client.Status().Update(ctx, managedOCS); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, storageClusterErr
+}
+
+func updateStorageClusterConditions(managedOCS *v1.ManagedOCS, storageCluster *ocsv1.StorageCluster) {
+	for _, mapping := range storageClusterConditionTypes {
+		scCondition := conditionsv1.FindStatusCondition(storageCluster.Status.Conditions, mapping.source)
+		if scCondition == nil {
+			utils.RemoveStatusCondition(&managedOCS.Status.Conditions, mapping.target)
+			continue
+		}
+
+		reason := scCondition.Reason
+		if reason == "" {
+			reason = "Unknown"
+		}
+		meta.SetStatusCondition(&managedOCS.Status.Conditions, metav1.Condition{
+			Type:               mapping.target,
+			Status:             metav1.ConditionStatus(scCondition.Status),
+			ObservedGeneration: managedOCS.Generation,
+			LastTransitionTime: scCondition.LastTransitionTime,
+			Reason:             reason,
+			Message:            scCondition.Message,
+		})
+	}
+}
+
+func removeStorageClusterConditions(managedOCS *v1.ManagedOCS) {
+	for _, mapping := range storageClusterConditionTypes {
+		utils.RemoveStatusCondition(&managedOCS.Status.Conditions, mapping.target)
+	}
+}
+
+// updateOSDDegradedCondition sets the OSDDegraded condition to True while the StorageCluster is
+// in the Error phase or reports itself as degraded, or while some of its OSD pods are not ready
+func updateOSDDegradedCondition(managedOCS *v1.ManagedOCS, storageCluster *ocsv1.StorageCluster, totalOSDs, readyOSDs int32) {
+	condition := metav1.Condition{
+		Type:               v1.ConditionOSDDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: managedOCS.Generation,
+		Reason:             "StorageClusterHealthy",
+		Message:            "The StorageCluster is not degraded",
+	}
+	storageClusterDegraded := storageCluster.Status.Phase == utils.PhaseError ||
+		conditionsv1.IsStatusConditionTrue(storageCluster.Status.Conditions, conditionsv1.ConditionDegraded)
+	if storageClusterDegraded || readyOSDs < totalOSDs {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "StorageClusterDegraded"
+		if !storageClusterDegraded {
+			condition.Reason = "OSDsNotReady"
+		}
+		condition.Message = fmt.Sprintf("The StorageCluster is degraded, %d OSDs are not ready out of %d",
+			totalOSDs-readyOSDs, totalOSDs)
+		if storageCluster.Status.FailureDomain != "" {
+			condition.Message += fmt.Sprintf(", failure domain is %s", storageCluster.Status.FailureDomain)
+		}
+	}
+	meta.SetStatusCondition(&managedOCS.Status.Conditions, condition)
+}
+
+func (r *StorageClusterWatcher) countOSDPods(ctx context.Context, namespace string) (int32, int32, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabels{osdLabelKey: osdLabelValue}); err != nil {
+		return 0, 0, fmt.Errorf("unable to list osd pods: %w", err)
+	}
+	total, ready := getOSDPodCounts(podList.Items)
+	return total, ready, nil
+}
+
+// getOSDPodCounts returns the number of OSD pods, and the number of those that are ready
+func getOSDPodCounts(pods []corev1.Pod) (int32, int32) {
+	var ready int32
+	for i := range pods {
+		if isPodReady(&pods[i]) {
+			ready++
+		}
+	}
+	return int32(len(pods)), ready
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isStorageClusterAvailable determines the readiness of the StorageCluster. The Available
+// condition is the most reliable signal, as the phase is a free form string that the OCS
+// operator also sets while some components are still being created. The phase is only
+// checked for StorageClusters that did not report any conditions yet.
+func isStorageClusterAvailable(storageCluster *ocsv1.StorageCluster) bool {
+	condition := conditionsv1.FindStatusCondition(storageCluster.Status.Conditions, conditionsv1.ConditionAvailable)
+	if condition != nil {
+		return condition.Status == corev1.ConditionTrue
+	}
+	return storageCluster.Status.Phase == utils.PhaseReady
+}
+
+// getManagedOCSPhase maps the phase reported by the StorageCluster to a ManagedOCS phase.
+// A StorageCluster that did not report a phase yet is still initializing.
+func getManagedOCSPhase(storageCluster *ocsv1.StorageCluster) v1.ManagedOCSPhase {
+	switch storageCluster.Status.Phase {
+	case utils.PhaseReady:
+		return v1.PhaseReady
+	case utils.PhaseError:
+		return v1.PhaseError
+	case "", utils.PhaseProgressing:
+		return v1.PhaseInitializing
+	default:
+		return v1.PhaseUnknown
+	}
+}
+
+// getStorageClusterCapacityBytes aggregates the raw capacity of the StorageCluster from the
+// storage request, count and replica of each of its storage device sets
+func getStorageClusterCapacityBytes(storageCluster *ocsv1.StorageCluster) int64 {
+	var total int64
+	for i := range storageCluster.Spec.StorageDeviceSets {
+		deviceSet := &storageCluster.Spec.StorageDeviceSets[i]
+		size, found := deviceSet.DataPVCTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !found {
+			continue
+		}
+		replica := deviceSet.Replica
+		if replica == 0 {
+			replica = defaultStorageDeviceSetReplica
+		}
+		total += size.Value() * int64(deviceSet.Count) * int64(replica)
+	}
+	return total
+}
@@ -0,0 +1,62 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Managed storage clusters", func() {
+	template := &corev1.LocalObjectReference{Name: "storagecluster-template"}
+
+	When("the ManagedOCS does not list storage clusters", func() {
+		It("should manage a single storage cluster named after spec.storageClusterName", func() {
+			managedOCS := &v1.ManagedOCS{}
+			Expect(getManagedStorageClusters(managedOCS)).To(Equal([]v1.ManagedStorageCluster{
+				{Name: v1.DefaultStorageClusterName},
+			}))
+
+			managedOCS.Spec.StorageClusterName = "test-storagecluster"
+			managedOCS.Spec.StorageClusterTemplate = template
+			Expect(getManagedStorageClusters(managedOCS)).To(Equal([]v1.ManagedStorageCluster{
+				{Name: "test-storagecluster", StorageClusterTemplate: template},
+			}))
+		})
+	})
+	When("the ManagedOCS lists storage clusters", func() {
+		It("should manage them in order, defaulting their template", func() {
+			dataTemplate := &corev1.LocalObjectReference{Name: "data-template"}
+			managedOCS := &v1.ManagedOCS{}
+			managedOCS.Spec.StorageClusterName = "ignored"
+			managedOCS.Spec.StorageClusterTemplate = template
+			managedOCS.Spec.StorageClusters = []v1.ManagedStorageCluster{
+				{Name: "metadata"},
+				{Name: "data", StorageClusterTemplate: dataTemplate},
+			}
+			Expect(getManagedStorageClusters(managedOCS)).To(Equal([]v1.ManagedStorageCluster{
+				{Name: "metadata", StorageClusterTemplate: template},
+				{Name: "data", StorageClusterTemplate: dataTemplate},
+			}))
+			Expect(getStorageClusterName(managedOCS)).To(Equal("metadata"))
+			Expect(managedOCS.Spec.StorageClusters[0].StorageClusterTemplate).To(BeNil())
+		})
+	})
+})
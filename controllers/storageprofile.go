@@ -0,0 +1,59 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// storageProfile is the count and the size of the storage device sets of a storage profile.
+// Each storage device set is replicated on 3 nodes
+type storageProfile struct {
+	count int
+	size  resource.Quantity
+}
+
+var storageProfiles = map[v1.StorageProfile]storageProfile{
+	v1.StorageProfileSmall:  {count: 1, size: resource.MustParse("1Ti")},
+	v1.StorageProfileMedium: {count: 1, size: resource.MustParse("4Ti")},
+	v1.StorageProfileLarge:  {count: 3, size: resource.MustParse("8Ti")},
+}
+
+// getStorageProfile returns the storage profile selected by managedOCS, it returns false for
+// the custom profile
+func getStorageProfile(managedOCS *v1.ManagedOCS) (storageProfile, bool) {
+	profile, found := storageProfiles[managedOCS.Spec.StorageProfile]
+	return profile, found
+}
+
+// applyStorageProfile sets the count and the size of the desired storage device sets to the
+// ones of the storage profile, keeping the count of the current storage device sets when it is
+// higher
+func applyStorageProfile(desired *ocsv1.StorageClusterSpec, current *ocsv1.StorageClusterSpec, profile storageProfile) {
+	applyAutoSizedDeviceSetCount(desired, current, profile.count)
+	for i := range desired.StorageDeviceSets {
+		resources := &desired.StorageDeviceSets[i].DataPVCTemplate.Spec.Resources
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[corev1.ResourceStorage] = profile.size.DeepCopy()
+	}
+}
@@ -0,0 +1,65 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/templates"
+)
+
+var _ = Describe("Storage profiles", func() {
+	It("should not select a profile for the custom profile", func() {
+		managedOCS := &v1.ManagedOCS{}
+		_, found := getStorageProfile(managedOCS)
+		Expect(found).To(BeFalse())
+		managedOCS.Spec.StorageProfile = v1.StorageProfileCustom
+		_, found = getStorageProfile(managedOCS)
+		Expect(found).To(BeFalse())
+	})
+
+	It("should set the count and the size of the storage device sets", func() {
+		managedOCS := &v1.ManagedOCS{}
+		managedOCS.Spec.StorageProfile = v1.StorageProfileLarge
+		profile, found := getStorageProfile(managedOCS)
+		Expect(found).To(BeTrue())
+
+		desired := newTestStorageClusterSpec(nil)
+		current := newTestStorageClusterSpec(nil)
+		applyStorageProfile(desired, current, profile)
+		for _, deviceSet := range desired.StorageDeviceSets {
+			Expect(deviceSet.Count).To(Equal(3))
+			Expect(deviceSet.DataPVCTemplate.Spec.Resources.Requests.Storage().Cmp(resource.MustParse("8Ti"))).To(BeZero())
+		}
+		Expect(templates.StorageClusterTemplate.Spec.StorageDeviceSets[0].DataPVCTemplate.Spec.Resources.Requests.Storage().String()).To(Equal("1Ti"))
+	})
+
+	It("should keep a higher current count", func() {
+		managedOCS := &v1.ManagedOCS{}
+		managedOCS.Spec.StorageProfile = v1.StorageProfileSmall
+		profile, _ := getStorageProfile(managedOCS)
+
+		desired := newTestStorageClusterSpec(nil)
+		current := newTestStorageClusterSpec(nil)
+		current.StorageDeviceSets[0].Count = 2
+		applyStorageProfile(desired, current, profile)
+		Expect(desired.StorageDeviceSets[0].Count).To(Equal(2))
+	})
+})
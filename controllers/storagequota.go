@@ -0,0 +1,138 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+)
+
+const (
+	storageQuotaName = "managed-ocs-storage-quota"
+
+	// StorageQuotaOwnerLabel holds the namespace of the ManagedOCS resource that created a
+	// storage ResourceQuota. ResourceQuotas in other namespaces cannot be owned by the
+	// ManagedOCS resource, so they are tracked through this label
+	StorageQuotaOwnerLabel = "ocs.openshift.io/storage-quota-owner"
+)
+
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;create;update;delete
+
+// reconcileStorageQuotas restricts the namespaces that can create PVCs against the
+// StorageClasses of the managed storage clusters to the allowed namespaces of the ManagedOCS
+// spec. A ResourceQuota allowing no PVC of these StorageClasses is created in each other
+// namespace, and deleted once the namespace is allowed. Namespaces created after the reconcile
+// are restricted by the next one
+func (r *ManagedOCSReconciler) reconcileStorageQuotas(ctx context.Context) error {
+	r.Log.Info("Reconciling storage ResourceQuotas")
+
+	var desired []corev1.ResourceQuota
+	if allowed := r.managedOCS.Spec.AllowedNamespaces; len(allowed) > 0 {
+		namespaceList := &corev1.NamespaceList{}
+		if err := r.UnrestrictedClient.List(ctx, namespaceList); err != nil {
+			return fmt.Errorf("Failed to list namespaces: %w", err)
+		}
+		var storageClassNames []string
+		for _, managedStorageCluster := range getManagedStorageClusters(r.managedOCS) {
+			sc := &ocsv1.StorageCluster{}
+			sc.Name = managedStorageCluster.Name
+			storageClassNames = append(storageClassNames, getExpectedStorageClassNames(sc)...)
+		}
+		desired = getDesiredStorageQuotas(namespaceList.Items, allowed, r.namespace, storageClassNames)
+	}
+
+	if err := r.pruneStorageQuotas(ctx, desired); err != nil {
+		return err
+	}
+	for i := range desired {
+		quota := &corev1.ResourceQuota{}
+		quota.Name = desired[i].Name
+		quota.Namespace = desired[i].Namespace
+		_, err := ctrl.CreateOrUpdate(ctx, r.UnrestrictedClient, quota, func() error {
+			if quota.Labels == nil {
+				quota.Labels = map[string]string{}
+			}
+			quota.Labels[StorageQuotaOwnerLabel] = r.namespace
+			quota.Spec = desired[i].Spec
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to update storage ResourceQuota in namespace %v: %w", quota.Namespace, err)
+		}
+	}
+	return nil
+}
+
+// pruneStorageQuotas deletes the storage ResourceQuotas created for the ManagedOCS resource in
+// the namespaces without a desired ResourceQuota. They are not garbage collected with the
+// ManagedOCS resource, so all of them are pruned on its deletion
+func (r *ManagedOCSReconciler) pruneStorageQuotas(ctx context.Context, desired []corev1.ResourceQuota) error {
+	existingList := &corev1.ResourceQuotaList{}
+	if err := r.UnrestrictedClient.List(ctx, existingList, client.MatchingLabels{StorageQuotaOwnerLabel: r.namespace}); err != nil {
+		return fmt.Errorf("Failed to list storage ResourceQuotas: %w", err)
+	}
+	desiredNamespaces := map[string]bool{}
+	for i := range desired {
+		desiredNamespaces[desired[i].Namespace] = true
+	}
+	for i := range existingList.Items {
+		existing := &existingList.Items[i]
+		if desiredNamespaces[existing.Namespace] {
+			continue
+		}
+		if err := r.UnrestrictedClient.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("Failed to delete storage ResourceQuota in namespace %v: %w", existing.Namespace, err)
+		}
+	}
+	return nil
+}
+
+// getDesiredStorageQuotas returns the ResourceQuotas allowing no PVC of the StorageClasses in the
+// namespaces that are neither allowed nor the namespace of the ManagedOCS resource
+func getDesiredStorageQuotas(namespaces []corev1.Namespace, allowed []string, ownNamespace string,
+	storageClassNames []string) []corev1.ResourceQuota {
+	allowedNamespaces := map[string]bool{ownNamespace: true}
+	for _, namespace := range allowed {
+		allowedNamespaces[namespace] = true
+	}
+
+	hard := corev1.ResourceList{}
+	for _, name := range storageClassNames {
+		hard[corev1.ResourceName(name+".storageclass.storage.k8s.io/persistentvolumeclaims")] = resource.MustParse("0")
+	}
+
+	var quotas []corev1.ResourceQuota
+	for i := range namespaces {
+		namespace := &namespaces[i]
+		if allowedNamespaces[namespace.Name] || !namespace.DeletionTimestamp.IsZero() {
+			continue
+		}
+		quota := corev1.ResourceQuota{}
+		quota.Name = storageQuotaName
+		quota.Namespace = namespace.Name
+		quota.Spec.Hard = hard.DeepCopy()
+		quotas = append(quotas, quota)
+	}
+	return quotas
+}
@@ -0,0 +1,50 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Storage ResourceQuotas", func() {
+	newNamespace := func(name string) corev1.Namespace {
+		return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	storageClassNames := []string{"ocs-storagecluster-ceph-rbd", "ocs-storagecluster-cephfs"}
+
+	It("should restrict the namespaces that are not allowed", func() {
+		namespaces := []corev1.Namespace{newNamespace("primary"), newNamespace("tenant-a"), newNamespace("tenant-b")}
+		quotas := getDesiredStorageQuotas(namespaces, []string{"tenant-a"}, "primary", storageClassNames)
+		Expect(quotas).To(HaveLen(1))
+		Expect(quotas[0].Name).To(Equal(storageQuotaName))
+		Expect(quotas[0].Namespace).To(Equal("tenant-b"))
+		Expect(quotas[0].Spec.Hard).To(HaveLen(2))
+		pvcs := quotas[0].Spec.Hard[corev1.ResourceName("ocs-storagecluster-cephfs.storageclass.storage.k8s.io/persistentvolumeclaims")]
+		Expect(pvcs.IsZero()).To(BeTrue())
+	})
+
+	It("should skip the namespaces being deleted", func() {
+		terminating := newNamespace("tenant-b")
+		now := metav1.Now()
+		terminating.DeletionTimestamp = &now
+		quotas := getDesiredStorageQuotas([]corev1.Namespace{terminating}, []string{"tenant-a"}, "primary", storageClassNames)
+		Expect(quotas).To(BeEmpty())
+	})
+})
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -25,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -63,6 +65,7 @@ const (
 	testGrafanaFederateSecretName              = "grafana-datasources"
 	testK8sMetricsServiceMonitorAuthSecretName = "k8s-metrics-service-monitor-auth"
 	testOpenshiftMonitoringNamespace           = "openshift-monitoring"
+	testStorageNodeNamePrefix                  = "test-storage-node"
 )
 
 func TestAPIs(t *testing.T) {
@@ -111,11 +114,25 @@ var _ = BeforeSuite(func(done Done) {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	// Client to be use by the test code, using a non cached client
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(k8sClient).ToNot(BeNil())
+
+	ctx := context.Background()
+
+	// Create the primary namespace to be used by some of the tests, it is validated when
+	// setting up the ManagedOCS controller
+	primaryNS := &corev1.Namespace{}
+	primaryNS.Name = testPrimaryNamespace
+	Expect(k8sClient.Create(ctx, primaryNS)).Should(Succeed())
+
 	err = (&ManagedOCSReconciler{
 		Client:                       k8sManager.GetClient(),
 		UnrestrictedClient:           k8sManager.GetClient(),
 		Log:                          ctrl.Log.WithName("controllers").WithName("ManagedOCS"),
 		Scheme:                       scheme.Scheme,
+		Namespace:                    testPrimaryNamespace,
 		AddonParamSecretName:         testAddonParamsSecretName,
 		AddonConfigMapName:           testAddonConfigMapName,
 		AddonConfigMapDeleteLabelKey: testAddonConfigMapDeleteLabelKey,
@@ -125,22 +142,61 @@ var _ = BeforeSuite(func(done Done) {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	go func() {
-		err = k8sManager.Start(ctrl.SetupSignalHandler())
-		Expect(err).ToNot(HaveOccurred())
-	}()
+	err = (&StorageClusterWatcher{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("StorageClusterWatcher"),
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
 
-	// Client to be use by the test code, using a non cached client
-	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	err = (&StorageClassReconciler{
+		Client:    k8sManager.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("StorageClass"),
+		Scheme:    scheme.Scheme,
+		Namespace: testPrimaryNamespace,
+	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
-	Expect(k8sClient).ToNot(BeNil())
 
-	ctx := context.Background()
+	err = (&PDBReconciler{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("PDB"),
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
 
-	// Create the primary namespace to be used by some of the tests
-	primaryNS := &corev1.Namespace{}
-	primaryNS.Name = testPrimaryNamespace
-	Expect(k8sClient.Create(ctx, primaryNS)).Should(Succeed())
+	err = (&NetworkPolicyReconciler{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("NetworkPolicy"),
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&BackupPolicyReconciler{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("BackupPolicy"),
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&FleetStatusReconciler{
+		Client:    k8sManager.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("FleetStatus"),
+		Scheme:    scheme.Scheme,
+		Namespace: testPrimaryNamespace,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MigrationReconciler{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Migration"),
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	go func() {
+		err = k8sManager.Start(ctrl.SetupSignalHandler())
+		Expect(err).ToNot(HaveOccurred())
+	}()
 
 	// Create a secondary namespace to be used by some of the tests
 	secondaryNS := &corev1.Namespace{}
@@ -151,6 +207,24 @@ var _ = BeforeSuite(func(done Done) {
 	openshiftMonitoringNS.Name = testOpenshiftMonitoringNamespace
 	Expect(k8sClient.Create(ctx, openshiftMonitoringNS)).Should(Succeed())
 
+	// Create the storage nodes and the storage class required by the storage cluster
+	for i := 0; i < 3; i++ {
+		node := &corev1.Node{}
+		node.Name = fmt.Sprintf("%s-%d", testStorageNodeNamePrefix, i)
+		node.Labels = map[string]string{"node-role.kubernetes.io/worker": ""}
+		Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+		node.Status.Conditions = []corev1.NodeCondition{{
+			Type:   corev1.NodeReady,
+			Status: corev1.ConditionTrue,
+		}}
+		Expect(k8sClient.Status().Update(ctx, node)).Should(Succeed())
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	storageClass.Name = "gp2"
+	storageClass.Provisioner = "kubernetes.io/aws-ebs"
+	Expect(k8sClient.Create(ctx, storageClass)).Should(Succeed())
+
 	// Create a mock subscription
 	deployerSub := &opv1a1.Subscription{}
 	deployerSub.Name = testSubscriptionName
@@ -173,6 +247,8 @@ var _ = BeforeSuite(func(done Done) {
 	ocsCSV.Spec.InstallStrategy.StrategyName = "test-strategy"
 	ocsCSV.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = getMockOCSCSVDeploymentSpec()
 	Expect(k8sClient.Create(ctx, ocsCSV)).ShouldNot(HaveOccurred())
+	ocsCSV.Status.Phase = opv1a1.CSVPhaseSucceeded
+	Expect(k8sClient.Status().Update(ctx, ocsCSV)).ShouldNot(HaveOccurred())
 
 	// Create the ManagedOCS resource
 	managedOCS := &v1.ManagedOCS{}
@@ -0,0 +1,57 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/ocs-osd-deployer/templates"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const eventReasonTemplateUpgradeBlocked = "TemplateUpgradeBlocked"
+
+// checkStorageClusterTemplateUpgrade checks whether the desired storage cluster can be applied
+// to sc. A template whose version differs from the one applied to the existing primary storage
+// cluster is only applied when the ManagedOCS allows the upgrade, so it does not silently
+// overwrite the customizations of the storage cluster. The changes are logged either way.
+// Templates without a version are not checked
+func (r *ManagedOCSReconciler) checkStorageClusterTemplateUpgrade(sc, desired *ocsv1.StorageCluster) bool {
+	version := desired.Annotations[templates.StorageClusterTemplateVersionAnnotation]
+	applied := r.managedOCS.Status.AppliedTemplateVersion
+	if !r.isPrimaryStorageCluster() || sc.CreationTimestamp.IsZero() || applied == "" || version == "" || applied == version {
+		return true
+	}
+
+	log := r.Log.WithValues("appliedVersion", applied, "version", version)
+	diff, err := utils.DiffStorageCluster(sc, desired)
+	if err != nil {
+		log.Error(err, "Unable to compute storage cluster template upgrade diff")
+	} else {
+		log.Info("Storage cluster template version changed", "diff", diff)
+	}
+
+	if !r.managedOCS.Spec.AllowTemplateUpgrade {
+		log.Info("Storage cluster template upgrade not allowed, skipping StorageCluster update")
+		r.recordEvent(corev1.EventTypeWarning, eventReasonTemplateUpgradeBlocked,
+			"StorageCluster template version %v differs from the applied version %v, "+
+				"set spec.allowTemplateUpgrade to apply it", version, applied)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	rook "github.com/rook/rook/pkg/apis/rook.io/v1"
+
+	"github.com/openshift/ocs-osd-deployer/templates"
+)
+
+// newTestStorageClusterSpec returns a copy of the built-in StorageCluster spec with two storage
+// device sets, to check that changes are applied to every storage device set. The placement of
+// the storage device sets is replaced by placement, unless it is nil
+func newTestStorageClusterSpec(placement *rook.Placement) *ocsv1.StorageClusterSpec {
+	spec := templates.StorageClusterTemplate.Spec.DeepCopy()
+	spec.StorageDeviceSets = append(spec.StorageDeviceSets, *spec.StorageDeviceSets[0].DeepCopy())
+	if placement != nil {
+		for i := range spec.StorageDeviceSets {
+			placement.DeepCopyInto(&spec.StorageDeviceSets[i].Placement)
+		}
+	}
+	return spec
+}
@@ -0,0 +1,56 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	rook "github.com/rook/rook/pkg/apis/rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Storage device set tolerations", func() {
+	templateToleration := corev1.Toleration{
+		Key:      "node.ocs.openshift.io/storage",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "true",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	templatePlacement := &rook.Placement{Tolerations: []corev1.Toleration{templateToleration}}
+
+	When("no tolerations are set", func() {
+		It("should keep the template tolerations", func() {
+			spec := newTestStorageClusterSpec(templatePlacement)
+			applyTolerations(spec, nil)
+			Expect(spec).To(Equal(newTestStorageClusterSpec(templatePlacement)))
+		})
+	})
+	When("tolerations are set", func() {
+		It("should add them to all the storage device sets, without duplicates", func() {
+			toleration := corev1.Toleration{
+				Key:      "node-role.kubernetes.io/infra",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoExecute,
+			}
+			spec := newTestStorageClusterSpec(templatePlacement)
+			applyTolerations(spec, []corev1.Toleration{templateToleration, toleration})
+			for _, deviceSet := range spec.StorageDeviceSets {
+				Expect(deviceSet.Placement.Tolerations).To(Equal([]corev1.Toleration{templateToleration, toleration}))
+			}
+		})
+	})
+})
@@ -0,0 +1,158 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+const (
+	rookConfigOverrideName = "rook-config-override"
+	rookConfigOverrideKey  = "config"
+
+	// The tuning policies are rendered in a block of their own, so the Ceph configuration set
+	// by the OCS operator is left untouched
+	tuningPolicyBlockBegin = "# BEGIN ManagedOCS tuning policies"
+	tuningPolicyBlockEnd   = "# END ManagedOCS tuning policies"
+)
+
+// reconcileTuningPolicies applies the Ceph configuration options of the TuningPolicy resources
+// referencing the ManagedOCS resource to the Rook Ceph configuration override
+func (r *ManagedOCSReconciler) reconcileTuningPolicies(ctx context.Context) error {
+	if r.isDryRun() {
+		return nil
+	}
+	r.Log.Info("Reconciling TuningPolicies")
+
+	policyList := &v1.TuningPolicyList{}
+	if err := r.list(ctx, policyList, client.InNamespace(r.namespace)); err != nil {
+		return fmt.Errorf("unable to list tuning policies: %w", err)
+	}
+	cephConfig := mergeTuningPolicies(policyList.Items, r.managedOCS.Name)
+
+	configOverride := &corev1.ConfigMap{}
+	configOverride.Name = rookConfigOverrideName
+	configOverride.Namespace = r.namespace
+	if len(cephConfig) == 0 {
+		// Without any option to set, only an existing tuning policy block has to be removed
+		if err := r.get(ctx, configOverride); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, configOverride, func() error {
+		if configOverride.Data == nil {
+			configOverride.Data = map[string]string{}
+		}
+		configOverride.Data[rookConfigOverrideKey] = renderTuningPolicyBlock(configOverride.Data[rookConfigOverrideKey], cephConfig)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to apply tuning policies: %w", err)
+	}
+	return nil
+}
+
+// mergeTuningPolicies merges the Ceph configuration options of the policies referencing the
+// named ManagedOCS resource, in policy name order
+func mergeTuningPolicies(policies []v1.TuningPolicy, managedOCSName string) map[string]string {
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	cephConfig := map[string]string{}
+	for _, policy := range policies {
+		if policy.Spec.ManagedOCSRef.Name != managedOCSName {
+			continue
+		}
+		for key, val := range policy.Spec.CephConfig {
+			cephConfig[key] = val
+		}
+	}
+	return cephConfig
+}
+
+// renderTuningPolicyBlock replaces the tuning policy block of the Ceph configuration with a
+// global section holding cephConfig, sorted by option name. The block is removed when there
+// are no options to set.
+func renderTuningPolicyBlock(config string, cephConfig map[string]string) string {
+	return renderCephConfigBlock(config, tuningPolicyBlockBegin, tuningPolicyBlockEnd,
+		map[string]map[string]string{"global": cephConfig})
+}
+
+// renderCephConfigBlock replaces the block of the Ceph configuration delimited by the begin and
+// end markers with the given sections, sorted by section and option name. The block is removed
+// when there are no options to set.
+func renderCephConfigBlock(config string, blockBegin string, blockEnd string, sections map[string]map[string]string) string {
+	if begin := strings.Index(config, blockBegin); begin >= 0 {
+		end := strings.Index(config[begin:], blockEnd)
+		if end < 0 {
+			config = config[:begin]
+		} else {
+			config = config[:begin] + config[begin+end+len(blockEnd):]
+		}
+		config = strings.TrimRight(config, "\n")
+		if config != "" {
+			config += "\n"
+		}
+	}
+
+	sectionNames := make([]string, 0, len(sections))
+	for section, options := range sections {
+		if len(options) > 0 {
+			sectionNames = append(sectionNames, section)
+		}
+	}
+	if len(sectionNames) == 0 {
+		return config
+	}
+	sort.Strings(sectionNames)
+
+	var block strings.Builder
+	block.WriteString(blockBegin + "\n")
+	for _, section := range sectionNames {
+		options := sections[section]
+		keys := make([]string, 0, len(options))
+		for key := range options {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(&block, "[%s]\n", section)
+		for _, key := range keys {
+			fmt.Fprintf(&block, "%s = %s\n", key, options[key])
+		}
+	}
+	block.WriteString(blockEnd + "\n")
+
+	if config != "" && !strings.HasSuffix(config, "\n") {
+		config += "\n"
+	}
+	return config + block.String()
+}
@@ -0,0 +1,74 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+var _ = Describe("Tuning policies", func() {
+	newPolicy := func(name, managedOCSName string, cephConfig map[string]string) v1.TuningPolicy {
+		policy := v1.TuningPolicy{}
+		policy.Name = name
+		policy.Spec.ManagedOCSRef.Name = managedOCSName
+		policy.Spec.CephConfig = cephConfig
+		return policy
+	}
+	ocsConfig := "[global]\nmon_osd_full_ratio = .85\n"
+
+	When("several policies set the same option", func() {
+		It("should keep the value of the last policy in name order", func() {
+			policies := []v1.TuningPolicy{
+				newPolicy("b-policy", managedOCSName, map[string]string{"osd_memory_target": "8589934592"}),
+				newPolicy("a-policy", managedOCSName, map[string]string{"osd_memory_target": "4294967296", "debug_osd": "0/0"}),
+				newPolicy("c-policy", "other", map[string]string{"osd_memory_target": "1"}),
+			}
+			Expect(mergeTuningPolicies(policies, managedOCSName)).To(Equal(map[string]string{
+				"osd_memory_target": "8589934592",
+				"debug_osd":         "0/0",
+			}))
+		})
+	})
+
+	When("the configuration has no tuning policy block", func() {
+		It("should append the block after the existing configuration", func() {
+			config := renderTuningPolicyBlock(ocsConfig, map[string]string{"osd_memory_target": "1", "debug_osd": "0/0"})
+			Expect(config).To(Equal(ocsConfig +
+				tuningPolicyBlockBegin + "\n[global]\ndebug_osd = 0/0\nosd_memory_target = 1\n" + tuningPolicyBlockEnd + "\n"))
+		})
+	})
+
+	When("the configuration already has a tuning policy block", func() {
+		It("should replace the block", func() {
+			config := renderTuningPolicyBlock(ocsConfig, map[string]string{"osd_memory_target": "1"})
+			config = renderTuningPolicyBlock(config, map[string]string{"osd_memory_target": "2"})
+			Expect(config).To(Equal(ocsConfig +
+				tuningPolicyBlockBegin + "\n[global]\nosd_memory_target = 2\n" + tuningPolicyBlockEnd + "\n"))
+		})
+	})
+
+	When("there are no options left to set", func() {
+		It("should remove the block", func() {
+			config := renderTuningPolicyBlock(ocsConfig, map[string]string{"osd_memory_target": "1"})
+			Expect(renderTuningPolicyBlock(config, map[string]string{})).To(Equal(ocsConfig))
+			Expect(renderTuningPolicyBlock("", map[string]string{})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,107 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package errors defines the errors returned by the reconcile phases of the deployer. The
+// errors carry the failing component, a reason used in the ManagedOCS conditions and whether
+// the reconcile is expected to succeed when retried.
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+)
+
+// ReconcileError holds the details shared by the reconcile errors
+type ReconcileError struct {
+	// Component is the name of the resource that failed to reconcile
+	Component string
+
+	// Reason is a CamelCase identifier of the failure
+	Reason string
+
+	// Retryable is set when the failure is expected to resolve itself on a later reconcile
+	Retryable bool
+
+	// Err is the underlying error
+	Err error
+}
+
+func (e *ReconcileError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Component, e.Reason, e.Err)
+}
+
+func (e *ReconcileError) Unwrap() error {
+	return e.Err
+}
+
+// StorageClusterError is returned when the StorageCluster cannot be created or updated
+type StorageClusterError struct {
+	ReconcileError
+}
+
+// PreflightError is returned when the preflight checks of the StorageCluster cannot be run
+type PreflightError struct {
+	ReconcileError
+}
+
+// TemplateError is returned when the StorageCluster template cannot be loaded or rendered
+type TemplateError struct {
+	ReconcileError
+}
+
+// ReadinessError is returned when a component the StorageCluster depends on is not ready
+type ReadinessError struct {
+	ReconcileError
+}
+
+// NewStorageClusterError returns a StorageClusterError for the StorageCluster named component
+func NewStorageClusterError(component string, reason string, retryable bool, err error) error {
+	return &StorageClusterError{ReconcileError{component, reason, retryable, err}}
+}
+
+// NewPreflightError returns a PreflightError for the StorageCluster named component
+func NewPreflightError(component string, reason string, retryable bool, err error) error {
+	return &PreflightError{ReconcileError{component, reason, retryable, err}}
+}
+
+// NewTemplateError returns a TemplateError for the template named component
+func NewTemplateError(component string, reason string, retryable bool, err error) error {
+	return &TemplateError{ReconcileError{component, reason, retryable, err}}
+}
+
+// NewReadinessError returns a ReadinessError for the component that is not ready
+func NewReadinessError(component string, reason string, retryable bool, err error) error {
+	return &ReadinessError{ReconcileError{component, reason, retryable, err}}
+}
+
+// AsReconcileError finds the outermost reconcile error in the chain of err
+func AsReconcileError(err error) (*ReconcileError, bool) {
+	for ; err != nil; err = goerrors.Unwrap(err) {
+		switch e := err.(type) {
+		case *StorageClusterError:
+			return &e.ReconcileError, true
+		case *PreflightError:
+			return &e.ReconcileError, true
+		case *TemplateError:
+			return &e.ReconcileError, true
+		case *ReadinessError:
+			return &e.ReconcileError, true
+		case *ReconcileError:
+			return e, true
+		}
+	}
+	return nil, false
+}
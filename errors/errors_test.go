@@ -0,0 +1,62 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reconcile errors", func() {
+	cause := goerrors.New("connection refused")
+
+	It("should unwrap to the underlying error", func() {
+		err := NewStorageClusterError("test-storagecluster", "ReconcileFailed", true, cause)
+		Expect(goerrors.Is(err, cause)).Should(BeTrue())
+		Expect(err.Error()).Should(Equal("test-storagecluster: ReconcileFailed: connection refused"))
+	})
+
+	It("should be matched by their type", func() {
+		err := fmt.Errorf("wrapped: %w", NewTemplateError("template", "TemplateInvalid", false, cause))
+		var templateErr *TemplateError
+		Expect(goerrors.As(err, &templateErr)).Should(BeTrue())
+		Expect(templateErr.Component).Should(Equal("template"))
+
+		var preflightErr *PreflightError
+		Expect(goerrors.As(err, &preflightErr)).Should(BeFalse())
+	})
+
+	Context("AsReconcileError", func() {
+		It("should return the outermost reconcile error", func() {
+			inner := NewTemplateError("template", "TemplateInvalid", false, cause)
+			outer := NewStorageClusterError("test-storagecluster", "ReconcileFailed", true, inner)
+			reconcileErr, ok := AsReconcileError(fmt.Errorf("wrapped: %w", outer))
+			Expect(ok).Should(BeTrue())
+			Expect(reconcileErr.Reason).Should(Equal("ReconcileFailed"))
+			Expect(reconcileErr.Retryable).Should(BeTrue())
+		})
+		It("should not match other errors", func() {
+			_, ok := AsReconcileError(fmt.Errorf("wrapped: %w", cause))
+			Expect(ok).Should(BeFalse())
+			_, ok = AsReconcileError(nil)
+			Expect(ok).Should(BeFalse())
+		})
+	})
+})
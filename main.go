@@ -21,7 +21,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,11 +36,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	"github.com/go-logr/logr"
 	ocsv1 "github.com/openshift/ocs-operator/pkg/apis"
 	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/api/v1beta1"
 	"github.com/openshift/ocs-osd-deployer/controllers"
+	"github.com/openshift/ocs-osd-deployer/webhooks"
 	operators "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	promv1a1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
@@ -48,8 +55,29 @@ const (
 	namespaceEnvVarName   = "NAMESPACE"
 	addonNameEnvVarName   = "ADDON_NAME"
 	sopEndpointEnvVarName = "SOP_ENDPOINT"
+
+	enableWebhooksEnvVarName  = "ENABLE_WEBHOOKS"
+	operatorVersionEnvVarName = "OPERATOR_VERSION"
+
+	leaderElectionIDEnvVarName = "LEADER_ELECTION_ID"
+	leaseDurationEnvVarName    = "LEASE_DURATION_SECONDS"
+	renewDeadlineEnvVarName    = "RENEW_DEADLINE_SECONDS"
+	retryPeriodEnvVarName      = "RETRY_PERIOD_SECONDS"
+
+	defaultLeaderElectionID     = "e0c63ac0.openshift.io"
+	defaultLeaseDurationSeconds = 15
+	defaultRenewDeadlineSeconds = 10
+	defaultRetryPeriodSeconds   = 2
 )
 
+// leaderElectionConfig holds the leader election parameters of the manager
+type leaderElectionConfig struct {
+	id            string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -73,6 +101,8 @@ func addAllSchemes(scheme *runtime.Scheme) {
 
 	utilruntime.Must(v1.AddToScheme(scheme))
 
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
 	utilruntime.Must(operators.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
@@ -80,14 +110,28 @@ func addAllSchemes(scheme *runtime.Scheme) {
 
 func main() {
 	var metricsAddr string
+	var healthProbeAddr string
+	var livenessTimeout time.Duration
 	var enableLeaderElection bool
+	var minStorageDeviceSetCount int
+	var maxStorageDeviceSetCount int
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	// The readiness server sidecar already listens on 8081
+	flag.StringVar(&healthProbeAddr, "health-probe-addr", ":8082", "The address the liveness endpoint binds to.")
+	flag.DurationVar(&livenessTimeout, "liveness-timeout", controllers.DefaultLivenessTimeout,
+		"The time a reconcile can run before the manager is reported as not alive.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&minStorageDeviceSetCount, "min-storage-device-set-count", 1,
+		"The minimum storage device set count accepted for a ManagedOCS resource.")
+	flag.IntVar(&maxStorageDeviceSetCount, "max-storage-device-set-count", 20,
+		"The maximum storage device set count accepted for a ManagedOCS resource.")
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true), zap.StacktraceLevel(zapcore.ErrorLevel)))
+	// The log level can be adjusted at runtime through the OperatorConfig
+	logLevel := uberzap.NewAtomicLevelAt(zapcore.DebugLevel)
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true), zap.Level(&logLevel), zap.StacktraceLevel(zapcore.ErrorLevel)))
 
 	envVars, err := readEnvVars()
 	if err != nil {
@@ -95,13 +139,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	leaderElection, err := readLeaderElectionConfig()
+	if err != nil {
+		setupLog.Error(err, "Invalid leader election configuration")
+		os.Exit(1)
+	}
+	if enableLeaderElection {
+		setupLog.Info("Leader election configuration", "id", leaderElection.id,
+			"leaseDuration", leaderElection.leaseDuration,
+			"renewDeadline", leaderElection.renewDeadline,
+			"retryPeriod", leaderElection.retryPeriod)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: metricsAddr,
-		Port:               9443,
-		LeaderElection:     enableLeaderElection,
-		LeaderElectionID:   "e0c63ac0.openshift.io",
-		Namespace:          envVars[namespaceEnvVarName],
+		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
+		HealthProbeBindAddress: healthProbeAddr,
+		LivenessEndpointName:   "/livez",
+		Port:                   9443,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       leaderElection.id,
+		LeaseDuration:          &leaderElection.leaseDuration,
+		RenewDeadline:          &leaderElection.renewDeadline,
+		RetryPeriod:            &leaderElection.retryPeriod,
+		Namespace:              envVars[namespaceEnvVarName],
 	})
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager")
@@ -109,11 +170,12 @@ func main() {
 	}
 
 	addonName := envVars[addonNameEnvVarName]
-	if err = (&controllers.ManagedOCSReconciler{
+	managedOCSReconciler := &controllers.ManagedOCSReconciler{
 		Client:                       mgr.GetClient(),
 		UnrestrictedClient:           getUnrestrictedClient(),
 		Log:                          ctrl.Log.WithName("controllers").WithName("ManagedOCS"),
 		Scheme:                       mgr.GetScheme(),
+		Namespace:                    envVars[namespaceEnvVarName],
 		AddonParamSecretName:         fmt.Sprintf("addon-%v-parameters", addonName),
 		AddonConfigMapName:           addonName,
 		AddonConfigMapDeleteLabelKey: fmt.Sprintf("api.openshift.com/addon-%v-delete", addonName),
@@ -121,12 +183,83 @@ func main() {
 		PagerdutySecretName:          fmt.Sprintf("%v-pagerduty", addonName),
 		DeadMansSnitchSecretName:     fmt.Sprintf("%v-deadmanssnitch", addonName),
 		SOPEndpoint:                  envVars[sopEndpointEnvVarName],
-	}).SetupWithManager(mgr); err != nil {
+		LogLevel:                     &logLevel,
+		LivenessTimeout:              livenessTimeout,
+		OperatorVersion:              envVars[operatorVersionEnvVarName],
+	}
+	if err = managedOCSReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "ManagedOCS")
 		os.Exit(1)
 	}
+	if err = mgr.AddHealthzCheck("reconcile", managedOCSReconciler.LivenessCheck); err != nil {
+		setupLog.Error(err, "Unable to add the liveness check")
+		os.Exit(1)
+	}
+	if err = (&controllers.StorageClusterWatcher{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("StorageClusterWatcher"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "StorageClusterWatcher")
+		os.Exit(1)
+	}
+	if err = (&controllers.StorageClassReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("StorageClass"),
+		Scheme:    mgr.GetScheme(),
+		Namespace: envVars[namespaceEnvVarName],
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "StorageClass")
+		os.Exit(1)
+	}
+	if err = (&controllers.PDBReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("PDB"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "PDB")
+		os.Exit(1)
+	}
+	if err = (&controllers.NetworkPolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("NetworkPolicy"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "NetworkPolicy")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("BackupPolicy"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "BackupPolicy")
+		os.Exit(1)
+	}
+	if err = (&controllers.FleetStatusReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("FleetStatus"),
+		Scheme:    mgr.GetScheme(),
+		Namespace: envVars[namespaceEnvVarName],
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "FleetStatus")
+		os.Exit(1)
+	}
+	if err = (&controllers.MigrationReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Migration"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "Migration")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Webhooks can be disabled when running locally, where no serving certificates are available
+	if os.Getenv(enableWebhooksEnvVarName) != "false" {
+		setupWebhooks(mgr, int32(minStorageDeviceSetCount), int32(maxStorageDeviceSetCount))
+	}
+
 	if err := ensureManagedOCS(mgr.GetClient(), setupLog, envVars); err != nil {
 		os.Exit(1)
 	}
@@ -138,6 +271,24 @@ func main() {
 	}
 }
 
+func setupWebhooks(mgr ctrl.Manager, minStorageDeviceSetCount, maxStorageDeviceSetCount int32) {
+	webhookServer := mgr.GetWebhookServer()
+	webhookServer.Register(webhooks.ManagedOCSDefaulterPath, &webhook.Admission{
+		Handler: &webhooks.ManagedOCSDefaulter{
+			Log: ctrl.Log.WithName("webhooks").WithName("ManagedOCSDefaulter"),
+		},
+	})
+	webhookServer.Register(webhooks.ManagedOCSValidatorPath, &webhook.Admission{
+		Handler: &webhooks.ManagedOCSValidator{
+			Log:                      ctrl.Log.WithName("webhooks").WithName("ManagedOCSValidator"),
+			Client:                   mgr.GetClient(),
+			MinStorageDeviceSetCount: minStorageDeviceSetCount,
+			MaxStorageDeviceSetCount: maxStorageDeviceSetCount,
+		},
+	})
+	webhookServer.Register(webhooks.ConversionPath, &conversion.Webhook{})
+}
+
 // getUnrestrictedClient creates a client required for listing PVCs from all namespaces.
 func getUnrestrictedClient() client.Client {
 	var options client.Options
@@ -174,9 +325,52 @@ func readEnvVars() (map[string]string, error) {
 	}
 	envVars[sopEndpointEnvVarName] = val
 
+	// The operator version is only recorded in the status, it is left empty when not set
+	envVars[operatorVersionEnvVarName] = os.Getenv(operatorVersionEnvVarName)
+
 	return envVars, nil
 }
 
+// readLeaderElectionConfig reads the optional leader election environment variables,
+// falling back to the controller-runtime defaults
+func readLeaderElectionConfig() (*leaderElectionConfig, error) {
+	leaderElection := &leaderElectionConfig{id: defaultLeaderElectionID}
+	if val, found := os.LookupEnv(leaderElectionIDEnvVarName); found && val != "" {
+		leaderElection.id = val
+	}
+
+	var err error
+	if leaderElection.leaseDuration, err = readSecondsEnvVar(leaseDurationEnvVarName, defaultLeaseDurationSeconds); err != nil {
+		return nil, err
+	}
+	if leaderElection.renewDeadline, err = readSecondsEnvVar(renewDeadlineEnvVarName, defaultRenewDeadlineSeconds); err != nil {
+		return nil, err
+	}
+	if leaderElection.retryPeriod, err = readSecondsEnvVar(retryPeriodEnvVarName, defaultRetryPeriodSeconds); err != nil {
+		return nil, err
+	}
+
+	if leaderElection.renewDeadline >= leaderElection.leaseDuration {
+		return nil, fmt.Errorf("%s must be less than %s", renewDeadlineEnvVarName, leaseDurationEnvVarName)
+	}
+	if leaderElection.retryPeriod >= leaderElection.renewDeadline {
+		return nil, fmt.Errorf("%s must be less than %s", retryPeriodEnvVarName, renewDeadlineEnvVarName)
+	}
+	return leaderElection, nil
+}
+
+func readSecondsEnvVar(name string, defaultSeconds int) (time.Duration, error) {
+	val, found := os.LookupEnv(name)
+	if !found || val == "" {
+		return time.Duration(defaultSeconds) * time.Second, nil
+	}
+	seconds, err := strconv.Atoi(val)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("%s environment variable must be a positive number of seconds, got %q", name, val)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
 func ensureManagedOCS(c client.Client, log logr.Logger, envVars map[string]string) error {
 	err := c.Create(context.Background(), &v1.ManagedOCS{
 		ObjectMeta: metav1.ObjectMeta{
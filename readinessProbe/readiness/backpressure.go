@@ -0,0 +1,87 @@
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// backpressureHandler rejects the creation of the PVCs of the StorageClasses provisioned by the
+// OCS CSI drivers of the namespace of the ManagedOCS while its Backpressure condition is set.
+// The deployer only registers it as a webhook while the condition is set, the condition is
+// checked again so a stale webhook configuration does not reject anything
+type backpressureHandler struct {
+	client             client.Client
+	managedOCSResource types.NamespacedName
+	log                logr.Logger
+}
+
+// Handle validates PVC create requests
+func (h *backpressureHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := json.Unmarshal(req.Object.Raw, pvc); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	storageClassName := getStorageClassName(pvc)
+	if storageClassName == "" {
+		return admission.Allowed("")
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: storageClassName}, storageClass); err != nil {
+		if errors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !isManagedProvisioner(storageClass.Provisioner, h.managedOCSResource.Namespace) {
+		return admission.Allowed("")
+	}
+
+	managedOCS := &v1.ManagedOCS{}
+	if err := h.client.Get(ctx, h.managedOCSResource, managedOCS); err != nil {
+		if errors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionBackpressure)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return admission.Allowed("")
+	}
+
+	h.log.Info("Rejecting PVC while the storage applies backpressure",
+		"name", pvc.Name, "namespace", req.Namespace, "storageClass", storageClassName)
+	return admission.Denied(fmt.Sprintf(
+		"PVCs of StorageClass %q are not admitted while the storage cannot take more load: %s",
+		storageClassName, condition.Message,
+	))
+}
+
+// getStorageClassName returns the StorageClass of the PVC, set either in its spec or through the
+// deprecated beta annotation
+func getStorageClassName(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName != nil {
+		return *pvc.Spec.StorageClassName
+	}
+	return pvc.Annotations[corev1.BetaStorageClassAnnotation]
+}
+
+// isManagedProvisioner reports whether the provisioner is one of the OCS CSI drivers deployed in
+// the given namespace, which are named after it
+func isManagedProvisioner(provisioner string, namespace string) bool {
+	return strings.HasPrefix(provisioner, namespace+".") && strings.HasSuffix(provisioner, ".csi.ceph.com")
+}
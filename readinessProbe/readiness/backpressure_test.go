@@ -0,0 +1,27 @@
+package readiness
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("PVC backpressure", func() {
+	It("should only match the OCS CSI drivers of the namespace", func() {
+		Expect(isManagedProvisioner("openshift-storage.rbd.csi.ceph.com", "openshift-storage")).To(BeTrue())
+		Expect(isManagedProvisioner("openshift-storage.cephfs.csi.ceph.com", "openshift-storage")).To(BeTrue())
+		Expect(isManagedProvisioner("other.rbd.csi.ceph.com", "openshift-storage")).To(BeFalse())
+		Expect(isManagedProvisioner("kubernetes.io/aws-ebs", "openshift-storage")).To(BeFalse())
+	})
+
+	It("should read the StorageClass from the spec or the beta annotation", func() {
+		name := "ocs-storagecluster-ceph-rbd"
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvc.Spec.StorageClassName = &name
+		Expect(getStorageClassName(pvc)).To(Equal(name))
+
+		pvc = &corev1.PersistentVolumeClaim{}
+		pvc.Annotations = map[string]string{corev1.BetaStorageClassAnnotation: name}
+		Expect(getStorageClassName(pvc)).To(Equal(name))
+	})
+})
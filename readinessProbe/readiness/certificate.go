@@ -0,0 +1,41 @@
+package readiness
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// servingCertificate provides the certificate of the TLS server. The OpenShift service CA creates
+// the certificate after the pod may have started and rotates it, so it is loaded on handshake and
+// loaded again whenever the certificate file changes
+type servingCertificate struct {
+	certFile string
+	keyFile  string
+
+	mutex   sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (c *servingCertificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	info, err := os.Stat(c.certFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find serving certificate: %w", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.cert == nil || !info.ModTime().Equal(c.modTime) {
+		cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load serving certificate: %w", err)
+		}
+		c.cert = &cert
+		c.modTime = info.ModTime()
+	}
+	return c.cert, nil
+}
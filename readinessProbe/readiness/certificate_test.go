@@ -0,0 +1,79 @@
+package readiness
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Serving certificate", func() {
+	var certDir string
+	var cert *servingCertificate
+
+	writeCertificate := func(serial int64, modTime time.Time) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "readiness-server"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).ToNot(HaveOccurred())
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(cert.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(cert.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)).To(Succeed())
+		Expect(os.Chtimes(cert.certFile, modTime, modTime)).To(Succeed())
+	}
+	getSerial := func() int64 {
+		tlsCert, err := cert.GetCertificate(nil)
+		Expect(err).ToNot(HaveOccurred())
+		parsed, err := x509.ParseCertificate(tlsCert.Certificate[0])
+		Expect(err).ToNot(HaveOccurred())
+		return parsed.SerialNumber.Int64()
+	}
+
+	BeforeEach(func() {
+		var err error
+		certDir, err = ioutil.TempDir("", "serving-cert")
+		Expect(err).ToNot(HaveOccurred())
+		cert = &servingCertificate{
+			certFile: filepath.Join(certDir, "tls.crt"),
+			keyFile:  filepath.Join(certDir, "tls.key"),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(certDir)).To(Succeed())
+	})
+
+	It("should fail until the certificate is created", func() {
+		_, err := cert.GetCertificate(nil)
+		Expect(err).To(HaveOccurred())
+
+		writeCertificate(1, time.Now())
+		Expect(getSerial()).To(Equal(int64(1)))
+	})
+
+	It("should load the certificate again once it is rotated", func() {
+		now := time.Now()
+		writeCertificate(1, now)
+		Expect(getSerial()).To(Equal(int64(1)))
+
+		writeCertificate(2, now.Add(time.Minute))
+		Expect(getSerial()).To(Equal(int64(2)))
+	})
+})
@@ -0,0 +1,93 @@
+package readiness
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	storageClusterPhaseDesc = prometheus.NewDesc(
+		"storage_cluster_phase",
+		"Current phase of the StorageCluster, set to 1 for the reported phase",
+		[]string{"phase"},
+		nil,
+	)
+	storageClusterOSDCountDesc = prometheus.NewDesc(
+		"storage_cluster_osd_count",
+		"Number of OSDs requested by the StorageCluster device sets",
+		nil,
+		nil,
+	)
+	storageClusterCapacityDesc = prometheus.NewDesc(
+		"storage_cluster_capacity_bytes",
+		"Raw capacity in bytes requested by the StorageCluster device sets",
+		nil,
+		nil,
+	)
+)
+
+// storageClusterCollector reads the StorageCluster of the ManagedOCS on every scrape and reports
+// its status as gauges. Nothing is reported while the StorageCluster does not exist.
+type storageClusterCollector struct {
+	client             client.Client
+	managedOCSResource types.NamespacedName
+	log                logr.Logger
+}
+
+func (c *storageClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storageClusterPhaseDesc
+	ch <- storageClusterOSDCountDesc
+	ch <- storageClusterCapacityDesc
+}
+
+func (c *storageClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	var managedOCS v1.ManagedOCS
+	if err := c.client.Get(context.Background(), c.managedOCSResource, &managedOCS); err != nil {
+		if !errors.IsNotFound(err) {
+			c.log.Error(err, "error getting managedocs for metrics\n")
+			ch <- prometheus.NewInvalidMetric(storageClusterPhaseDesc, err)
+		}
+		return
+	}
+
+	var storageCluster ocsv1.StorageCluster
+	storageClusterResource := types.NamespacedName{
+		Name:      getStorageClusterName(&managedOCS),
+		Namespace: c.managedOCSResource.Namespace,
+	}
+	if err := c.client.Get(context.Background(), storageClusterResource, &storageCluster); err != nil {
+		if !errors.IsNotFound(err) {
+			c.log.Error(err, "error getting storagecluster for metrics\n")
+			ch <- prometheus.NewInvalidMetric(storageClusterPhaseDesc, err)
+		}
+		return
+	}
+
+	osdCount, capacityBytes := getStorageClusterCapacity(&storageCluster)
+
+	ch <- prometheus.MustNewConstMetric(storageClusterPhaseDesc, prometheus.GaugeValue, 1, storageCluster.Status.Phase)
+	ch <- prometheus.MustNewConstMetric(storageClusterOSDCountDesc, prometheus.GaugeValue, float64(osdCount))
+	ch <- prometheus.MustNewConstMetric(storageClusterCapacityDesc, prometheus.GaugeValue, float64(capacityBytes))
+}
+
+// getStorageClusterCapacity returns the number of OSDs and the raw capacity in bytes
+// requested by the device sets of a StorageCluster
+func getStorageClusterCapacity(storageCluster *ocsv1.StorageCluster) (int64, int64) {
+	var osdCount, capacityBytes int64
+	for _, deviceSet := range storageCluster.Spec.StorageDeviceSets {
+		osds := int64(deviceSet.Count) * int64(deviceSet.Replica)
+		osdCount += osds
+
+		storage := deviceSet.DataPVCTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+		capacityBytes += osds * storage.Value()
+	}
+	return osdCount, capacityBytes
+}
@@ -2,33 +2,149 @@ package readiness
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"path/filepath"
 
 	"github.com/go-logr/logr"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
 	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 const (
 	listenAddr          string = ":8081"
+	tlsListenAddr       string = ":8443"
 	readinessPath       string = "/readyz/"
+	healthPath          string = "/healthz/"
+	metricsPath         string = "/metrics/storagecluster"
+	backpressurePath    string = "/backpressure"
+	tlsCertDir          string = "/etc/tls/private"
 	NamespaceEnvVarName string = "NAMESPACE"
 )
 
-func isReady(client client.Client, managedOCSResource types.NamespacedName) (bool, error) {
+// Names of the readiness conditions
+const (
+	storageClusterCondition string = "StorageCluster"
+	prometheusCondition     string = "Prometheus"
+	alertmanagerCondition   string = "Alertmanager"
+	capacityCondition       string = "Capacity"
+)
+
+// readinessConditions maps the name of each readiness condition to the reason the deployment
+// is not ready, an empty reason means the condition is met
+type readinessConditions map[string]string
+
+// isReady reports whether all the readiness conditions are met
+func (c readinessConditions) isReady() bool {
+	for _, reason := range c {
+		if reason != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadinessStatus is the body returned by the readiness endpoint
+type ReadinessStatus struct {
+	Ready                bool              `json:"ready"`
+	Conditions           map[string]string `json:"conditions"`
+	StorageClusterPhase  string            `json:"storageClusterPhase"`
+	ManagedOCSGeneration int64             `json:"managedOCSGeneration"`
+	LastReconcileTime    *metav1.Time      `json:"lastReconcileTime,omitempty"`
+}
+
+func getReadinessStatus(client client.Client, managedOCSResource types.NamespacedName) (*ReadinessStatus, error) {
 
 	var managedOCS v1.ManagedOCS
 
 	if err := client.Get(context.Background(), managedOCSResource, &managedOCS); err != nil {
-		return false, err
+		return nil, err
 	}
 
-	ready := managedOCS.Status.Components.StorageCluster.State == v1.ComponentReady &&
-		managedOCS.Status.Components.Prometheus.State == v1.ComponentReady &&
-		managedOCS.Status.Components.Alertmanager.State == v1.ComponentReady
+	conditions := getReadinessConditions(&managedOCS)
 
-	return ready, nil
+	// The storage cluster phase is informative only, a missing storage cluster is
+	// already reflected in the ManagedOCS component status
+	var storageCluster ocsv1.StorageCluster
+	storageClusterResource := types.NamespacedName{
+		Name:      getStorageClusterName(&managedOCS),
+		Namespace: managedOCSResource.Namespace,
+	}
+	if err := client.Get(context.Background(), storageClusterResource, &storageCluster); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return &ReadinessStatus{
+		Ready:                conditions.isReady(),
+		Conditions:           conditions,
+		StorageClusterPhase:  storageCluster.Status.Phase,
+		ManagedOCSGeneration: managedOCS.Generation,
+		LastReconcileTime:    managedOCS.Status.LastReconcileTime,
+	}, nil
+}
+
+// getStorageClusterName returns the name of the primary StorageCluster of the ManagedOCS, as
+// referenced in its status once the deployer created it, or else as set in its spec
+func getStorageClusterName(managedOCS *v1.ManagedOCS) string {
+	if ref := managedOCS.Status.StorageClusterRef; ref != nil && ref.Name != "" {
+		return ref.Name
+	}
+	if len(managedOCS.Spec.StorageClusters) > 0 {
+		return managedOCS.Spec.StorageClusters[0].Name
+	}
+	if managedOCS.Spec.StorageClusterName != "" {
+		return managedOCS.Spec.StorageClusterName
+	}
+	return v1.DefaultStorageClusterName
+}
+
+// getReadinessConditions evaluates the readiness conditions of the deployment from the
+// ManagedOCS status
+func getReadinessConditions(managedOCS *v1.ManagedOCS) readinessConditions {
+	components := managedOCS.Status.Components
+	conditions := readinessConditions{
+		storageClusterCondition: getComponentNotReadyReason(components.StorageCluster),
+		prometheusCondition:     getComponentNotReadyReason(components.Prometheus),
+		alertmanagerCondition:   getComponentNotReadyReason(components.Alertmanager),
+		capacityCondition:       getCapacityNotReadyReason(managedOCS),
+	}
+	// The deployment is drained for an upgrade of the operator, it takes no more load
+	if managedOCS.Spec.MaintenanceMode == v1.MaintenanceModeDrainAndPause {
+		for name := range conditions {
+			conditions[name] = v1.MaintenanceModeReason
+		}
+	}
+	return conditions
+}
+
+func getComponentNotReadyReason(component v1.ComponentStatus) string {
+	if component.State == v1.ComponentReady {
+		return ""
+	}
+	return fmt.Sprintf("component state is %q", component.State)
+}
+
+// getCapacityNotReadyReason reports the storage utilization once it exceeded the critical
+// level, in which case the deployment is not ready to take more load
+func getCapacityNotReadyReason(managedOCS *v1.ManagedOCS) string {
+	condition := meta.FindStatusCondition(managedOCS.Status.Conditions, v1.ConditionCapacityWarning)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != v1.CapacityCriticalReason {
+		return ""
+	}
+	if condition.Message == "" {
+		return condition.Reason
+	}
+	return condition.Message
 }
 
 func RunServer(client client.Client, managedOCSResource types.NamespacedName, log logr.Logger) error {
@@ -40,7 +156,7 @@ func RunServer(client client.Client, managedOCSResource types.NamespacedName, lo
 	// "Any other code indicates failure."
 	// [indicates that the deployment is not ready]
 	http.HandleFunc(readinessPath, func(httpw http.ResponseWriter, req *http.Request) {
-		ready, err := isReady(client, managedOCSResource)
+		status, err := getReadinessStatus(client, managedOCSResource)
 
 		if err != nil {
 			log.Error(err, "error checking readiness\n")
@@ -48,12 +164,69 @@ func RunServer(client client.Client, managedOCSResource types.NamespacedName, lo
 			return
 		}
 
-		if ready {
+		body, err := json.Marshal(status)
+		if err != nil {
+			log.Error(err, "error encoding readiness status\n")
+			httpw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		httpw.Header().Set("Content-Type", "application/json")
+		if status.Ready {
 			httpw.WriteHeader(http.StatusOK)
 		} else {
 			httpw.WriteHeader(http.StatusServiceUnavailable)
 		}
+		if _, err := httpw.Write(body); err != nil {
+			log.Error(err, "error writing readiness status\n")
+		}
+	})
+
+	// Liveness of the server itself, does not query the API server
+	http.HandleFunc(healthPath, func(httpw http.ResponseWriter, req *http.Request) {
+		httpw.WriteHeader(http.StatusOK)
 	})
 
+	// StorageCluster status exposed as Prometheus gauges, scraped directly
+	// without federating from the OCS monitoring stack
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(&storageClusterCollector{
+		client:             client,
+		managedOCSResource: managedOCSResource,
+		log:                log,
+	}); err != nil {
+		return err
+	}
+	http.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	// Admission of the PVCs while the storage applies backpressure. The API server only calls
+	// webhooks over TLS, so it is served with the certificate of the readiness server Service
+	// created by the OpenShift service CA
+	backpressureWebhook := &admission.Webhook{
+		Handler: &backpressureHandler{
+			client:             client,
+			managedOCSResource: managedOCSResource,
+			log:                log.WithName("backpressure"),
+		},
+	}
+	if err := backpressureWebhook.InjectLogger(log.WithName("backpressure")); err != nil {
+		return err
+	}
+	http.Handle(backpressurePath, backpressureWebhook)
+	tlsServer := &http.Server{
+		Addr: tlsListenAddr,
+		TLSConfig: &tls.Config{
+			GetCertificate: (&servingCertificate{
+				certFile: filepath.Join(tlsCertDir, "tls.crt"),
+				keyFile:  filepath.Join(tlsCertDir, "tls.key"),
+			}).GetCertificate,
+		},
+	}
+	go func() {
+		if err := tlsServer.ListenAndServeTLS("", ""); err != nil {
+			log.Error(err, "TLS server error")
+		}
+	}()
+
 	return http.ListenAndServe(listenAddr, nil)
 }
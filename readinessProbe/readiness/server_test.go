@@ -6,8 +6,11 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
 	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
 	utils "github.com/openshift/ocs-osd-deployer/testutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -109,5 +112,126 @@ var _ = Describe("ManagedOCS readiness probe behavior", func() {
 			})
 		})
 
+		When("managedocs reports a critical storage utilization", func() {
+			It("should cause the readiness probe to return StatusServiceUnavailable", func() {
+				Expect(setupReadinessConditions(true, true, true)).Should(Succeed())
+				managedOCS.Status.Conditions = []metav1.Condition{{
+					Type:               v1.ConditionCapacityWarning,
+					Status:             metav1.ConditionTrue,
+					Reason:             v1.CapacityCriticalReason,
+					LastTransitionTime: metav1.Now(),
+				}}
+				Expect(k8sClient.Status().Update(ctx, managedOCS)).Should(Succeed())
+
+				status, err := utils.ProbeReadiness()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(http.StatusServiceUnavailable))
+			})
+		})
+
+		When("managedocs is in the DrainAndPause maintenance mode", func() {
+			It("should report every readiness condition as not ready", func() {
+				Expect(setupReadinessConditions(true, true, true)).Should(Succeed())
+				managedOCS.Spec.MaintenanceMode = v1.MaintenanceModeDrainAndPause
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+
+				readinessStatus := ReadinessStatus{}
+				status, err := utils.ProbeReadinessStatus(&readinessStatus)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(http.StatusServiceUnavailable))
+				for _, reason := range readinessStatus.Conditions {
+					Expect(reason).To(Equal(v1.MaintenanceModeReason))
+				}
+
+				managedOCS.Spec.MaintenanceMode = ""
+				Expect(k8sClient.Update(ctx, managedOCS)).Should(Succeed())
+			})
+		})
+
+		When("the storagecluster reports its phase", func() {
+			It("should include the phase and the managedocs generation in the readiness status", func() {
+				storageCluster := &ocsv1.StorageCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      v1.DefaultStorageClusterName,
+						Namespace: TestNamespace,
+					},
+				}
+				Expect(k8sClient.Create(ctx, storageCluster)).Should(Succeed())
+				storageCluster.Status.Phase = "Error"
+				Expect(k8sClient.Status().Update(ctx, storageCluster)).Should(Succeed())
+
+				Expect(setupReadinessConditions(false, true, true)).Should(Succeed())
+
+				readinessStatus := ReadinessStatus{}
+				status, err := utils.ProbeReadinessStatus(&readinessStatus)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(http.StatusServiceUnavailable))
+				Expect(readinessStatus.Ready).To(BeFalse())
+				Expect(readinessStatus.Conditions).To(HaveKeyWithValue(storageClusterCondition, Not(BeEmpty())))
+				Expect(readinessStatus.Conditions).To(HaveKeyWithValue(prometheusCondition, BeEmpty()))
+				Expect(readinessStatus.StorageClusterPhase).To(Equal("Error"))
+				Expect(readinessStatus.ManagedOCSGeneration).To(Equal(managedOCS.Generation))
+
+				Expect(k8sClient.Delete(ctx, storageCluster)).Should(Succeed())
+			})
+		})
+
+	})
+
+	Context("StorageCluster metrics", func() {
+		When("the storagecluster exists", func() {
+			It("should expose its phase, osd count and capacity as gauges", func() {
+				storageCluster := &ocsv1.StorageCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      v1.DefaultStorageClusterName,
+						Namespace: TestNamespace,
+					},
+					Spec: ocsv1.StorageClusterSpec{
+						StorageDeviceSets: []ocsv1.StorageDeviceSet{{
+							Name:    "default",
+							Count:   2,
+							Replica: 3,
+							DataPVCTemplate: corev1.PersistentVolumeClaim{
+								Spec: corev1.PersistentVolumeClaimSpec{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceStorage: resource.MustParse("1Ti"),
+										},
+									},
+								},
+							},
+						}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, storageCluster)).Should(Succeed())
+				storageCluster.Status.Phase = "Ready"
+				Expect(k8sClient.Status().Update(ctx, storageCluster)).Should(Succeed())
+
+				status, body, err := utils.ScrapeStorageClusterMetrics()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(body).To(ContainSubstring(`storage_cluster_phase{phase="Ready"} 1`))
+				Expect(body).To(ContainSubstring("storage_cluster_osd_count 6"))
+				Expect(body).To(ContainSubstring("storage_cluster_capacity_bytes 6.597069766656e+12"))
+
+				Expect(k8sClient.Delete(ctx, storageCluster)).Should(Succeed())
+			})
+		})
+	})
+})
+
+var _ = Describe("StorageCluster name", func() {
+	It("should prefer the status reference, then the spec, then the default name", func() {
+		managedOCS := &v1.ManagedOCS{}
+		Expect(getStorageClusterName(managedOCS)).To(Equal(v1.DefaultStorageClusterName))
+
+		managedOCS.Spec.StorageClusterName = "custom-storagecluster"
+		Expect(getStorageClusterName(managedOCS)).To(Equal("custom-storagecluster"))
+
+		managedOCS.Spec.StorageClusters = []v1.ManagedStorageCluster{{Name: "primary"}, {Name: "data"}}
+		Expect(getStorageClusterName(managedOCS)).To(Equal("primary"))
+
+		managedOCS.Status.StorageClusterRef = &corev1.LocalObjectReference{Name: "adopted"}
+		Expect(getStorageClusterName(managedOCS)).To(Equal("adopted"))
 	})
 })
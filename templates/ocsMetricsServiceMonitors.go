@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CephMgrServiceMonitorTemplate scrapes the Prometheus module of the Ceph mgr, through the
+// service rook creates for it
+var CephMgrServiceMonitorTemplate = promv1.ServiceMonitor{
+	Spec: promv1.ServiceMonitorSpec{
+		Endpoints: []promv1.Endpoint{{
+			Port: "http-metrics",
+			Path: "/metrics",
+		}},
+		Selector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": "rook-ceph-mgr",
+			},
+		},
+	},
+}
+
+// OCSMetricsExporterServiceMonitorTemplate scrapes the metrics exporter deployed by the ocs-operator
+var OCSMetricsExporterServiceMonitorTemplate = promv1.ServiceMonitor{
+	Spec: promv1.ServiceMonitorSpec{
+		Endpoints: []promv1.Endpoint{{
+			Port: "metrics",
+			Path: "/metrics",
+		}},
+		Selector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/name": "ocs-metrics-exporter",
+			},
+		},
+	},
+}
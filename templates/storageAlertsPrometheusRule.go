@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// StorageAlertsPrometheusRuleTemplate holds the default alerting rules of the OCS storage, tuned
+// for the managed service
+var StorageAlertsPrometheusRuleTemplate = promv1.PrometheusRule{
+	Spec: promv1.PrometheusRuleSpec{
+		Groups: []promv1.RuleGroup{
+			{
+				Name: "managed-ocs-storage.rules",
+				Rules: []promv1.Rule{
+					{
+						Alert: "ManagedOCSStorageNearFull",
+						Expr: intstr.IntOrString{
+							Type:   intstr.String,
+							StrVal: "ceph_cluster_total_used_raw_bytes / ceph_cluster_total_bytes > 0.75",
+						},
+						For: "10m",
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+						Annotations: map[string]string{
+							"message": "The storage cluster utilization has crossed 75%.",
+						},
+					},
+					{
+						Alert: "ManagedOCSStorageCriticallyFull",
+						Expr: intstr.IntOrString{
+							Type:   intstr.String,
+							StrVal: "ceph_cluster_total_used_raw_bytes / ceph_cluster_total_bytes > 0.85",
+						},
+						For: "5m",
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+						Annotations: map[string]string{
+							"message": "The storage cluster utilization has crossed 85% and will become read-only at 95%.",
+						},
+					},
+					{
+						Alert: "ManagedOCSCephClusterErrorState",
+						Expr: intstr.IntOrString{
+							Type:   intstr.String,
+							StrVal: "ceph_health_status == 2",
+						},
+						For: "10m",
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+						Annotations: map[string]string{
+							"message": "The Ceph cluster has been in the error state for more than 10 minutes.",
+						},
+					},
+				},
+			},
+		},
+	},
+}
@@ -25,11 +25,25 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// StorageClusterTemplateVersionAnnotation holds the version of a storage cluster template. It
+// is set on the built-in template, and read from the ConfigMap of a template provided in one
+const StorageClusterTemplateVersionAnnotation = "ocs.openshift.io/template-version"
+
+// StorageClusterTemplateVersion is the version of the built-in storage cluster template. It
+// must be bumped with every change of the template, so the change is not applied to existing
+// storage clusters unless the ManagedOCS allows the upgrade
+const StorageClusterTemplateVersion = "1.0.0"
+
 // StorageClusterTemplate is the template that serves as the base for the storage clsuter deployed by the operator
 var gp2 = "gp2"
 var volumeModeBlock = corev1.PersistentVolumeBlock
 
 var StorageClusterTemplate = ocsv1.StorageCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			StorageClusterTemplateVersionAnnotation: StorageClusterTemplateVersion,
+		},
+	},
 	Spec: ocsv1.StorageClusterSpec{
 		// The label selector is used to select only the worker nodes for
 		// both labeling and scheduling.
@@ -3,6 +3,7 @@ package testutils
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"time"
 
@@ -72,6 +73,31 @@ func ProbeReadiness() (int, error) {
 	return resp.StatusCode, nil
 }
 
+func ProbeReadinessStatus(into interface{}) (int, error) {
+	resp, err := http.Get("http://localhost:8081/readyz")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return resp.StatusCode, err
+	}
+	return resp.StatusCode, nil
+}
+
+func ScrapeStorageClusterMetrics() (int, string, error) {
+	resp, err := http.Get("http://localhost:8081/metrics/storagecluster")
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+	return resp.StatusCode, string(body), nil
+}
+
 func ToJsonOrDie(value interface{}) []byte {
 	if bytes, err := json.Marshal(value); err == nil {
 		return bytes
@@ -0,0 +1,50 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ZoneTopologyKey is the node label holding the zone of the node
+	ZoneTopologyKey = "topology.kubernetes.io/zone"
+
+	defaultAntiAffinityWeight = 100
+)
+
+// GetDefaultPodAntiAffinity returns a pod anti-affinity spreading the pods carrying the given
+// label across zones. It is a preference, so the pods are still scheduled on clusters with
+// fewer zones than pods
+func GetDefaultPodAntiAffinity(labelKey string, labelValue string) *corev1.PodAntiAffinity {
+	return &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight: defaultAntiAffinityWeight,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      labelKey,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{labelValue},
+					}},
+				},
+				TopologyKey: ZoneTopologyKey,
+			},
+		}},
+	}
+}
@@ -0,0 +1,45 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+)
+
+// cephConfForbiddenChars cannot appear in the sections, options and values of the Ceph
+// configuration, as they would change the structure of the rendered configuration file
+const cephConfForbiddenChars = "[]=#;\r\n"
+
+// ParseCephConfKey splits a Ceph configuration key in the section/option format, e.g.
+// osd/osd_scrub_max_interval, and reports whether it is valid
+func ParseCephConfKey(key string) (string, string, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	section, option := parts[0], parts[1]
+	if section == "" || option == "" ||
+		strings.ContainsAny(section, cephConfForbiddenChars+" \t") || strings.ContainsAny(option, cephConfForbiddenChars) {
+		return "", "", false
+	}
+	return section, option, true
+}
+
+// IsValidCephConfValue checks whether the value can be rendered in the Ceph configuration
+func IsValidCephConfValue(value string) bool {
+	return !strings.ContainsAny(value, "\r\n")
+}
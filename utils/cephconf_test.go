@@ -0,0 +1,45 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ceph configuration keys", func() {
+	It("should split valid keys into a section and an option", func() {
+		section, option, ok := ParseCephConfKey("osd/osd_scrub_max_interval")
+		Expect(ok).To(BeTrue())
+		Expect(section).To(Equal("osd"))
+		Expect(option).To(Equal("osd_scrub_max_interval"))
+	})
+
+	It("should reject keys that are not in the section/option format", func() {
+		for _, key := range []string{"osd_scrub_max_interval", "osd/", "/osd_scrub_max_interval", "a/b/c"} {
+			_, _, ok := ParseCephConfKey(key)
+			Expect(ok).To(BeFalse(), key)
+		}
+	})
+
+	It("should reject keys and values changing the structure of the configuration", func() {
+		_, _, ok := ParseCephConfKey("global]\n[osd/debug_osd")
+		Expect(ok).To(BeFalse())
+		Expect(IsValidCephConfValue("0/0\nauth_cluster_required = none")).To(BeFalse())
+		Expect(IsValidCephConfValue("0/0")).To(BeTrue())
+	})
+})
@@ -0,0 +1,155 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+)
+
+// diffContextLines is the number of unchanged lines around each change
+const diffContextLines = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// DiffStorageCluster returns a unified diff of the indented JSON representations of the current
+// and desired StorageCluster specs. The diff is empty when the specs are equal.
+func DiffStorageCluster(current, desired *ocsv1.StorageCluster) (string, error) {
+	currentJSON, err := json.MarshalIndent(&current.Spec, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal current storage cluster spec: %w", err)
+	}
+	desiredJSON, err := json.MarshalIndent(&desired.Spec, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal desired storage cluster spec: %w", err)
+	}
+	lines := diffLines(strings.Split(string(currentJSON), "\n"), strings.Split(string(desiredJSON), "\n"))
+	return formatUnifiedDiff(lines, "current", "desired"), nil
+}
+
+// diffLines computes the shortest edit script between a and b from their longest common
+// subsequence. The specs are small enough for the quadratic table.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// formatUnifiedDiff groups the changed lines in hunks surrounded by unchanged context lines
+func formatUnifiedDiff(lines []diffLine, fromName, toName string) string {
+	// Line numbers, in the old and new text, of each line of the edit script
+	fromLine := make([]int, len(lines)+1)
+	toLine := make([]int, len(lines)+1)
+	for k, line := range lines {
+		fromLine[k+1], toLine[k+1] = fromLine[k], toLine[k]
+		if line.op != '+' {
+			fromLine[k+1]++
+		}
+		if line.op != '-' {
+			toLine[k+1]++
+		}
+	}
+
+	var out strings.Builder
+	for k := 0; k < len(lines); {
+		if lines[k].op == ' ' {
+			k++
+			continue
+		}
+
+		start := k - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := k
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			unchanged := 0
+			for end+unchanged < len(lines) && lines[end+unchanged].op == ' ' {
+				unchanged++
+			}
+			// Changes separated by more than twice the context go in separate hunks
+			if end+unchanged == len(lines) || unchanged > 2*diffContextLines {
+				if unchanged > diffContextLines {
+					unchanged = diffContextLines
+				}
+				end += unchanged
+				break
+			}
+			end += unchanged
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n",
+			fromLine[start]+1, fromLine[end]-fromLine[start],
+			toLine[start]+1, toLine[end]-toLine[start])
+		for _, line := range lines[start:end] {
+			out.WriteByte(line.op)
+			out.WriteString(line.text)
+			out.WriteByte('\n')
+		}
+		k = end
+	}
+	return out.String()
+}
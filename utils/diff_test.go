@@ -0,0 +1,78 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocsv1 "github.com/openshift/ocs-operator/pkg/apis/ocs/v1"
+)
+
+var _ = Describe("StorageCluster diff", func() {
+	newStorageCluster := func(count int) *ocsv1.StorageCluster {
+		sc := &ocsv1.StorageCluster{}
+		sc.Spec.StorageDeviceSets = []ocsv1.StorageDeviceSet{{Name: "default", Count: count}}
+		return sc
+	}
+
+	When("the specs are equal", func() {
+		It("should return an empty diff", func() {
+			diff, err := DiffStorageCluster(newStorageCluster(1), newStorageCluster(1))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+	})
+	When("the specs differ", func() {
+		It("should return the changed lines with their context", func() {
+			diff, err := DiffStorageCluster(newStorageCluster(1), newStorageCluster(3))
+			Expect(err).ToNot(HaveOccurred())
+			lines := strings.Split(diff, "\n")
+			Expect(lines[0]).To(Equal("--- current"))
+			Expect(lines[1]).To(Equal("+++ desired"))
+			Expect(lines[2]).To(HavePrefix("@@ -"))
+			Expect(lines).To(ContainElement(`-      "count": 1,`))
+			Expect(lines).To(ContainElement(`+      "count": 3,`))
+			Expect(lines).To(ContainElement(`       "name": "default",`))
+		})
+	})
+	When("lines are added and removed", func() {
+		It("should split distant changes in separate hunks", func() {
+			a := []string{"a", "1", "2", "3", "4", "5", "6", "7", "8", "b"}
+			b := []string{"A", "1", "2", "3", "4", "5", "6", "7", "8", "B", "c"}
+			Expect(formatUnifiedDiff(diffLines(a, b), "a", "b")).To(Equal(strings.Join([]string{
+				"--- a",
+				"+++ b",
+				"@@ -1,4 +1,4 @@",
+				"-a",
+				"+A",
+				" 1",
+				" 2",
+				" 3",
+				"@@ -7,4 +7,5 @@",
+				" 6",
+				" 7",
+				" 8",
+				"-b",
+				"+B",
+				"+c",
+				"",
+			}, "\n")))
+		})
+	})
+})
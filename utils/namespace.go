@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+)
+
+// NamespaceMetadataMissingReason is the reason of the preflight error returned when the
+// required namespace labels are missing and cannot be applied
+const NamespaceMetadataMissingReason = "NamespaceMetadataMissing"
+
+// RequiredNamespaceLabels are the labels Managed OpenShift requires on the operator namespace
+var RequiredNamespaceLabels = map[string]string{
+	"openshift.io/cluster-monitoring": "true",
+}
+
+// ValidateNamespace checks that the namespace carries the required labels. Missing ones are
+// applied when the client is allowed to patch the namespace, otherwise a PreflightError listing
+// them is returned
+func ValidateNamespace(ctx context.Context, c client.Client, namespace string) error {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("unable to get namespace %q: %w", namespace, err)
+	}
+
+	missingLabels := getMissingEntries(ns.Labels, RequiredNamespaceLabels)
+	if len(missingLabels) == 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	ns.Labels = mergeEntries(ns.Labels, RequiredNamespaceLabels)
+	if err := c.Patch(ctx, ns, patch); err != nil {
+		if errors.IsForbidden(err) {
+			err = fmt.Errorf("namespace %q is missing labels [%s]: %w",
+				namespace, strings.Join(missingLabels, ", "), err)
+			return deployererrors.NewPreflightError(namespace, NamespaceMetadataMissingReason, false, err)
+		}
+		return fmt.Errorf("unable to patch namespace %q: %w", namespace, err)
+	}
+	return nil
+}
+
+// getMissingEntries returns the sorted keys of required that are not set to the same value in
+// entries
+func getMissingEntries(entries map[string]string, required map[string]string) []string {
+	missing := []string{}
+	for key, value := range required {
+		if current, ok := entries[key]; !ok || current != value {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func mergeEntries(entries map[string]string, required map[string]string) map[string]string {
+	if entries == nil {
+		entries = map[string]string{}
+	}
+	for key, value := range required {
+		entries[key] = value
+	}
+	return entries
+}
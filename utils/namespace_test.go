@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	goerrors "errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployererrors "github.com/openshift/ocs-osd-deployer/errors"
+)
+
+// namespaceClient serves a single namespace and records the patches applied to it
+type namespaceClient struct {
+	client.Client
+	namespace *corev1.Namespace
+	patchErr  error
+	patched   bool
+}
+
+func (c *namespaceClient) Get(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+	c.namespace.DeepCopyInto(obj.(*corev1.Namespace))
+	return nil
+}
+
+func (c *namespaceClient) Patch(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+	if c.patchErr != nil {
+		return c.patchErr
+	}
+	c.patched = true
+	obj.(*corev1.Namespace).DeepCopyInto(c.namespace)
+	return nil
+}
+
+var _ = Describe("ValidateNamespace", func() {
+	var c *namespaceClient
+
+	BeforeEach(func() {
+		c = &namespaceClient{namespace: &corev1.Namespace{}}
+		c.namespace.Name = "openshift-storage"
+	})
+
+	When("the namespace carries the required labels", func() {
+		It("should leave the namespace untouched", func() {
+			c.namespace.Labels = map[string]string{"openshift.io/cluster-monitoring": "true"}
+			c.namespace.Annotations = map[string]string{"openshift.io/node-selector": "node-role.kubernetes.io/infra="}
+			Expect(ValidateNamespace(context.Background(), c, "openshift-storage")).Should(Succeed())
+			Expect(c.patched).Should(BeFalse())
+		})
+	})
+	When("the namespace is missing required labels", func() {
+		It("should apply it", func() {
+			c.namespace.Labels = map[string]string{"openshift.io/cluster-monitoring": "false", "team": "storage"}
+			Expect(ValidateNamespace(context.Background(), c, "openshift-storage")).Should(Succeed())
+			Expect(c.patched).Should(BeTrue())
+			Expect(c.namespace.Labels).Should(Equal(map[string]string{"openshift.io/cluster-monitoring": "true", "team": "storage"}))
+			Expect(c.namespace.Annotations).Should(BeEmpty())
+		})
+		It("should return a preflight error listing it when not allowed to apply it", func() {
+			c.patchErr = errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "openshift-storage", nil)
+			err := ValidateNamespace(context.Background(), c, "openshift-storage")
+			var preflightErr *deployererrors.PreflightError
+			Expect(goerrors.As(err, &preflightErr)).Should(BeTrue())
+			Expect(preflightErr.Reason).Should(Equal(NamespaceMetadataMissingReason))
+			Expect(err.Error()).Should(ContainSubstring("openshift.io/cluster-monitoring"))
+		})
+	})
+})
@@ -0,0 +1,25 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+// Phases reported by the OCS operator in the StorageCluster status. The ocs-operator API does
+// not export them, so they are mirrored here instead of being compared as string literals
+const (
+	PhaseReady       = "Ready"
+	PhaseProgressing = "Progressing"
+	PhaseError       = "Error"
+)
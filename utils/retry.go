@@ -0,0 +1,54 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// The delay between the attempts of RetryWithBackoff starts at retryInitialDelay and doubles
+// after each failed attempt, up to retryMaxDelay
+var (
+	retryInitialDelay = 100 * time.Millisecond
+	retryMaxDelay     = 5 * time.Second
+)
+
+// RetryWithBackoff calls fn until it succeeds or maxAttempts attempts have failed, waiting an
+// exponentially growing delay between attempts. It returns the last error of fn, or the error
+// of ctx if ctx is done before the next attempt
+func RetryWithBackoff(ctx context.Context, maxAttempts int, fn func() error) error {
+	delay := retryInitialDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= maxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	goerrors "errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryWithBackoff", func() {
+	var attempts int
+	var initialDelay time.Duration
+	errTransient := goerrors.New("transient")
+
+	// failTimes returns a function failing the given number of times before it succeeds
+	failTimes := func(failures int) func() error {
+		return func() error {
+			attempts++
+			if attempts <= failures {
+				return errTransient
+			}
+			return nil
+		}
+	}
+
+	BeforeEach(func() {
+		attempts = 0
+		initialDelay = retryInitialDelay
+		retryInitialDelay = time.Millisecond
+	})
+	AfterEach(func() {
+		retryInitialDelay = initialDelay
+	})
+
+	It("should not retry a successful call", func() {
+		Expect(RetryWithBackoff(context.Background(), 3, failTimes(0))).To(Succeed())
+		Expect(attempts).To(Equal(1))
+	})
+
+	It("should retry until the call succeeds", func() {
+		Expect(RetryWithBackoff(context.Background(), 3, failTimes(2))).To(Succeed())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should return the last error once all the attempts failed", func() {
+		Expect(RetryWithBackoff(context.Background(), 3, failTimes(3))).To(MatchError(errTransient))
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should stop retrying once the context is done", func() {
+		retryInitialDelay = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(RetryWithBackoff(ctx, 3, failTimes(3))).To(MatchError(context.Canceled))
+		Expect(attempts).To(Equal(1))
+	})
+})
@@ -0,0 +1,80 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	// 2021-03-10 is a Wednesday
+	now := time.Date(2021, time.March, 10, 10, 30, 15, 0, time.UTC)
+	next := func(spec string) time.Time {
+		schedule, err := Parse(spec)
+		Expect(err).ToNot(HaveOccurred())
+		return schedule.Next(now)
+	}
+
+	When("the schedule activates every minute", func() {
+		It("should activate on the next minute", func() {
+			Expect(next("* * * * *")).To(Equal(time.Date(2021, time.March, 10, 10, 31, 0, 0, time.UTC)))
+		})
+	})
+	When("the schedule uses steps", func() {
+		It("should activate on the next step", func() {
+			Expect(next("*/15 * * * *")).To(Equal(time.Date(2021, time.March, 10, 10, 45, 0, 0, time.UTC)))
+			Expect(next("0 */6 * * *")).To(Equal(time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC)))
+		})
+	})
+	When("the schedule activates daily", func() {
+		It("should activate on the next day once the time has passed", func() {
+			Expect(next("0 2 * * *")).To(Equal(time.Date(2021, time.March, 11, 2, 0, 0, 0, time.UTC)))
+		})
+	})
+	When("the schedule restricts the day of week", func() {
+		It("should activate on the next matching day", func() {
+			Expect(next("0 0 * * 0")).To(Equal(time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC)))
+			Expect(next("0 0 * * 1-5")).To(Equal(time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+	When("the schedule restricts both the day of month and the day of week", func() {
+		It("should activate on either of them", func() {
+			Expect(next("0 0 20 * 5")).To(Equal(time.Date(2021, time.March, 12, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+	When("the schedule restricts the month", func() {
+		It("should activate in the next matching month", func() {
+			Expect(next("0 0 1 1,7 *")).To(Equal(time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+	When("the schedule matches a nonexistent date", func() {
+		It("should never activate", func() {
+			Expect(next("0 0 30 2 *")).To(BeZero())
+		})
+	})
+	When("the schedule is invalid", func() {
+		It("should fail to parse", func() {
+			for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+				_, err := Parse(spec)
+				Expect(err).To(HaveOccurred(), "schedule %q", spec)
+			}
+		})
+	})
+})
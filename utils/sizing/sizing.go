@@ -0,0 +1,75 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sizing derives the size of the StorageCluster from the inventory of the storage nodes
+package sizing
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// StorageNodeLabelKey marks the nodes that host the OSDs of the StorageCluster
+	StorageNodeLabelKey = "cluster.ocs.openshift.io/openshift-storage"
+
+	// DeviceCountAnnotationPrefix, followed by a device class, is the annotation holding the
+	// number of devices of that class a storage node hosts. Nodes without the annotation
+	// host a single device
+	DeviceCountAnnotationPrefix = "ocs.openshift.io/device-count-"
+
+	// DeviceSetReplica is the number of failure domains each storage device set is spread across
+	DeviceSetReplica = 3
+)
+
+// Calculate returns the storage device set count matching the devices of the given device class
+// hosted by the storage nodes. Each device set spreads its devices across DeviceSetReplica failure
+// domains, so devices that do not fill a whole replica are left out.
+func Calculate(nodes []corev1.Node, deviceClass string) (count int, err error) {
+	annotationKey := DeviceCountAnnotationPrefix + deviceClass
+
+	var storageNodes, devices int
+	for i := range nodes {
+		node := &nodes[i]
+		if _, ok := node.Labels[StorageNodeLabelKey]; !ok {
+			continue
+		}
+		storageNodes++
+
+		val, ok := node.Annotations[annotationKey]
+		if !ok {
+			devices++
+			continue
+		}
+		nodeDevices, err := strconv.Atoi(val)
+		if err != nil || nodeDevices < 0 {
+			return 0, fmt.Errorf("node %s: invalid %s annotation value %q", node.Name, annotationKey, val)
+		}
+		devices += nodeDevices
+	}
+
+	if storageNodes == 0 {
+		return 0, fmt.Errorf("no node is labeled with %s", StorageNodeLabelKey)
+	}
+	count = devices / DeviceSetReplica
+	if count == 0 {
+		return 0, fmt.Errorf("found %d %s devices on %d storage nodes, at least %d are required",
+			devices, deviceClass, storageNodes, DeviceSetReplica)
+	}
+	return count, nil
+}
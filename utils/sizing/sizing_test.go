@@ -0,0 +1,101 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sizing
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Calculate", func() {
+	newNode := func(name string, storage bool, deviceCount string) corev1.Node {
+		node := corev1.Node{}
+		node.Name = name
+		if storage {
+			node.Labels = map[string]string{StorageNodeLabelKey: ""}
+		}
+		if deviceCount != "" {
+			node.Annotations = map[string]string{DeviceCountAnnotationPrefix + "ssd": deviceCount}
+		}
+		return node
+	}
+
+	When("the storage nodes are not annotated", func() {
+		It("should count a single device per storage node", func() {
+			nodes := []corev1.Node{
+				newNode("storage-0", true, ""),
+				newNode("storage-1", true, ""),
+				newNode("storage-2", true, ""),
+				newNode("worker-0", false, "6"),
+			}
+			Expect(Calculate(nodes, "ssd")).To(Equal(1))
+		})
+	})
+
+	When("the storage nodes are annotated with their device count", func() {
+		It("should only count whole replicas", func() {
+			nodes := []corev1.Node{
+				newNode("storage-0", true, "3"),
+				newNode("storage-1", true, "3"),
+				newNode("storage-2", true, "2"),
+			}
+			Expect(Calculate(nodes, "ssd")).To(Equal(2))
+		})
+	})
+
+	When("the annotations are for another device class", func() {
+		It("should count a single device per storage node", func() {
+			nodes := []corev1.Node{
+				newNode("storage-0", true, "4"),
+				newNode("storage-1", true, "4"),
+				newNode("storage-2", true, "4"),
+			}
+			Expect(Calculate(nodes, "hdd")).To(Equal(1))
+		})
+	})
+
+	When("there are not enough devices for a single replica", func() {
+		It("should fail", func() {
+			nodes := []corev1.Node{
+				newNode("storage-0", true, ""),
+				newNode("storage-1", true, ""),
+			}
+			_, err := Calculate(nodes, "ssd")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("there are no storage nodes", func() {
+		It("should fail", func() {
+			_, err := Calculate([]corev1.Node{newNode("worker-0", false, "")}, "ssd")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("a device count annotation is invalid", func() {
+		It("should fail", func() {
+			nodes := []corev1.Node{
+				newNode("storage-0", true, "three"),
+				newNode("storage-1", true, ""),
+				newNode("storage-2", true, ""),
+			}
+			_, err := Calculate(nodes, "ssd")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
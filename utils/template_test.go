@@ -0,0 +1,54 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template rendering", func() {
+	When("the template references its data", func() {
+		It("should substitute the values", func() {
+			out, err := RenderTemplate("test", "count: {{ .Count }}\nname: {{ .Spec.Name }}", map[string]interface{}{
+				"Count": 3,
+				"Spec":  struct{ Name string }{Name: "ocs"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("count: 3\nname: ocs"))
+		})
+	})
+	When("the template has no actions", func() {
+		It("should be rendered unchanged", func() {
+			out, err := RenderTemplate("test", "count: 1", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("count: 1"))
+		})
+	})
+	When("the template references a missing key", func() {
+		It("should fail", func() {
+			_, err := RenderTemplate("test", "count: {{ .Count }}", map[string]interface{}{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	When("the template is malformed", func() {
+		It("should fail", func() {
+			_, err := RenderTemplate("test", "count: {{ .Count ", map[string]interface{}{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -17,6 +17,7 @@ limitations under the License.
 package utils
 
 import (
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -50,3 +51,12 @@ func AddLabel(obj metav1.Object, key string, value string) {
 	}
 	labels[key] = value
 }
+
+// RemoveStatusCondition removes the condition of the given type from conditions. Unlike
+// meta.RemoveStatusCondition it is safe to call on an empty list of conditions
+func RemoveStatusCondition(conditions *[]metav1.Condition, conditionType string) {
+	if conditions == nil || len(*conditions) == 0 {
+		return
+	}
+	meta.RemoveStatusCondition(conditions, conditionType)
+}
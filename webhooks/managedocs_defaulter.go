@@ -0,0 +1,91 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+const (
+	ManagedOCSDefaulterPath = "/mutate-ocs-openshift-io-v1alpha1-managedocs"
+
+	CreatedByAnnotationKey = "ocs.openshift.io/created-by"
+)
+
+// +kubebuilder:webhook:path=/mutate-ocs-openshift-io-v1alpha1-managedocs,mutating=true,failurePolicy=fail,groups=ocs.openshift.io,resources=managedocs,verbs=create;update,versions=v1alpha1,name=mmanagedocs.ocs.openshift.io
+
+// ManagedOCSDefaulter sets defaults on ManagedOCS resources before they are persisted. Webhooks
+// can be disabled through ENABLE_WEBHOOKS, so the controllers apply the same defaults to the
+// fields left empty
+type ManagedOCSDefaulter struct {
+	Log     logr.Logger
+	decoder *admission.Decoder
+}
+
+// Handle defaults the reconcile strategy, the reclaim policy, the storage cluster name and the capacity alert
+// threshold, and records the creator of new ManagedOCS resources
+func (d *ManagedOCSDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	managedOCS := &v1.ManagedOCS{}
+	if err := d.decoder.Decode(req, managedOCS); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if managedOCS.Spec.ReconcileStrategy == "" {
+		managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyStrict
+	}
+	if managedOCS.Spec.ReclaimPolicy == "" {
+		managedOCS.Spec.ReclaimPolicy = v1.ReclaimPolicyRetain
+	}
+	if managedOCS.Spec.StorageClusterName == "" {
+		managedOCS.Spec.StorageClusterName = v1.DefaultStorageClusterName
+	}
+	if managedOCS.Spec.CapacityAlertThreshold == nil {
+		threshold := v1.DefaultCapacityAlertThreshold
+		managedOCS.Spec.CapacityAlertThreshold = &threshold
+	}
+
+	if req.Operation == admissionv1beta1.Create {
+		annotations := managedOCS.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if _, ok := annotations[CreatedByAnnotationKey]; !ok && req.UserInfo.Username != "" {
+			annotations[CreatedByAnnotationKey] = req.UserInfo.Username
+			managedOCS.SetAnnotations(annotations)
+		}
+	}
+
+	marshaled, err := json.Marshal(managedOCS)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// InjectDecoder injects the decoder into the defaulter
+func (d *ManagedOCSDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+// findPatch returns the value of the patch operation applied to path, if any
+func findPatch(resp admission.Response, path string) (interface{}, bool) {
+	for _, patch := range resp.Patches {
+		if patch.Path == path {
+			return patch.Value, true
+		}
+	}
+	return nil, false
+}
+
+var _ = Describe("ManagedOCSDefaulter", func() {
+	ctx := context.Background()
+
+	var defaulter *ManagedOCSDefaulter
+	var managedOCS *v1.ManagedOCS
+
+	BeforeEach(func() {
+		defaulter = &ManagedOCSDefaulter{Log: ctrl.Log.WithName("test")}
+		Expect(defaulter.InjectDecoder(testDecoder)).Should(Succeed())
+
+		managedOCS = &v1.ManagedOCS{}
+		managedOCS.APIVersion = v1.GroupVersion.String()
+		managedOCS.Kind = "ManagedOCS"
+		managedOCS.Name = "managedocs"
+		managedOCS.Namespace = "primary"
+	})
+
+	When("a ManagedOCS is created without a reconcile strategy", func() {
+		It("should default the strategy to strict, the reclaim policy to retain, the storage cluster name, the capacity alert threshold and record the creator", func() {
+			req := newManagedOCSRequest(admissionv1beta1.Create, managedOCS)
+			req.UserInfo = authenticationv1.UserInfo{Username: "test-user"}
+
+			resp := defaulter.Handle(ctx, req)
+			Expect(resp.Allowed).Should(BeTrue())
+
+			value, found := findPatch(resp, "/spec/reconcileStrategy")
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(Equal(string(v1.ReconcileStrategyStrict)))
+
+			value, found = findPatch(resp, "/spec/reclaimPolicy")
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(Equal(string(v1.ReclaimPolicyRetain)))
+
+			value, found = findPatch(resp, "/spec/storageClusterName")
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(Equal(v1.DefaultStorageClusterName))
+
+			value, found = findPatch(resp, "/spec/capacityAlertThreshold")
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(BeNumerically("==", v1.DefaultCapacityAlertThreshold))
+
+			value, found = findPatch(resp, "/metadata/annotations")
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(HaveKeyWithValue(CreatedByAnnotationKey, "test-user"))
+		})
+	})
+	When("a ManagedOCS is updated with all the defaulted fields set", func() {
+		It("should not modify the resource", func() {
+			threshold := int32(90)
+			managedOCS.Spec.CapacityAlertThreshold = &threshold
+			managedOCS.Spec.ReconcileStrategy = v1.ReconcileStrategyNone
+			managedOCS.Spec.ReclaimPolicy = v1.ReclaimPolicyDelete
+			managedOCS.Spec.StorageClusterName = "test-storagecluster"
+			req := newManagedOCSRequest(admissionv1beta1.Update, managedOCS)
+			req.UserInfo = authenticationv1.UserInfo{Username: "test-user"}
+
+			resp := defaulter.Handle(ctx, req)
+			Expect(resp.Allowed).Should(BeTrue())
+			Expect(resp.Patches).Should(BeEmpty())
+		})
+	})
+})
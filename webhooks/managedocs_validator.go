@@ -0,0 +1,438 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+	"github.com/openshift/ocs-osd-deployer/utils"
+)
+
+const (
+	ManagedOCSValidatorPath = "/validate-ocs-openshift-io-v1alpha1-managedocs"
+
+	hostNetworkProvider = "host"
+)
+
+var knownReconcileStrategies = []v1.ReconcileStrategy{
+	v1.ReconcileStrategyNone,
+	v1.ReconcileStrategyStrict,
+	v1.ReconcileStrategyForce,
+}
+
+// externalCephSecretKeys are the keys required in the Secret holding the credentials of an
+// external Ceph cluster
+var externalCephSecretKeys = []string{"userID", "userKey", "adminID", "adminKey", "monData"}
+
+// reservedLabelPrefixes are the prefixes of the labels that cannot be set on the StorageClusters
+var reservedLabelPrefixes = []string{"kubernetes.io/", "openshift.io/"}
+
+// imageOverrideComponents are the components whose images can be overridden
+var imageOverrideComponents = []string{
+	v1.ImageOverrideCeph,
+	v1.ImageOverrideRook,
+	v1.ImageOverrideNooBaa,
+	v1.ImageOverrideNooBaaDB,
+}
+
+var knownTaintEffects = []corev1.TaintEffect{
+	corev1.TaintEffectNoSchedule,
+	corev1.TaintEffectPreferNoSchedule,
+	corev1.TaintEffectNoExecute,
+}
+
+// +kubebuilder:webhook:path=/validate-ocs-openshift-io-v1alpha1-managedocs,mutating=false,failurePolicy=fail,groups=ocs.openshift.io,resources=managedocs,verbs=create;update,versions=v1alpha1,name=vmanagedocs.ocs.openshift.io
+
+// ManagedOCSValidator rejects invalid ManagedOCS resources. It checks that:
+//   - the reconcile strategy and the toleration effects are known
+//   - the storage cluster name, annotation keys and labels are valid, labels are not reserved
+//   - the storage device set count is in range
+//   - a storage profile is not combined with a storage device set count or auto sizing
+//   - the encryption, network, ceph cluster spec and maintenance window settings are valid
+//   - the migration soak duration is positive and the OCS version range is valid
+//   - image overrides and custom Ceph configuration options are known and allowed
+//   - the external Ceph secret exists and holds the credentials
+//
+// Updates cannot rename the storage cluster, disable encryption or decrease the storage device
+// set count
+type ManagedOCSValidator struct {
+	Log logr.Logger
+
+	// Client reads the Secret referenced by spec.externalCephSecretRef, the Secret is not
+	// checked when it is not set
+	Client client.Client
+
+	// MinStorageDeviceSetCount and MaxStorageDeviceSetCount bound spec.storageDeviceSetCount,
+	// a zero value leaves the corresponding bound unchecked
+	MinStorageDeviceSetCount int32
+	MaxStorageDeviceSetCount int32
+
+	decoder *admission.Decoder
+}
+
+// Handle validates ManagedOCS create and update requests
+func (v *ManagedOCSValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	managedOCS := &v1.ManagedOCS{}
+	if err := v.decoder.Decode(req, managedOCS); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	strategy := managedOCS.Spec.ReconcileStrategy
+	if strategy != "" && !isKnownReconcileStrategy(strategy) {
+		v.Log.Info("Rejecting ManagedOCS with an unknown reconcile strategy",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace, "reconcileStrategy", strategy)
+		return admission.Denied(fmt.Sprintf(
+			"spec.reconcileStrategy: unsupported value %q, supported values are %q",
+			strategy, knownReconcileStrategies,
+		))
+	}
+
+	if name := managedOCS.Spec.StorageClusterName; name != "" {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			v.Log.Info("Rejecting ManagedOCS with an invalid storage cluster name",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageClusterName", name)
+			return admission.Denied(fmt.Sprintf(
+				"spec.storageClusterName: invalid value %q: %s", name, strings.Join(errs, ", "),
+			))
+		}
+	}
+
+	names := map[string]bool{}
+	for i, storageCluster := range managedOCS.Spec.StorageClusters {
+		if errs := validation.IsDNS1123Subdomain(storageCluster.Name); len(errs) > 0 {
+			v.Log.Info("Rejecting ManagedOCS with an invalid storage cluster name",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageClusterName", storageCluster.Name)
+			return admission.Denied(fmt.Sprintf(
+				"spec.storageClusters[%d].name: invalid value %q: %s", i, storageCluster.Name, strings.Join(errs, ", "),
+			))
+		}
+		if names[storageCluster.Name] {
+			v.Log.Info("Rejecting ManagedOCS with a duplicate storage cluster name",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageClusterName", storageCluster.Name)
+			return admission.Denied(fmt.Sprintf(
+				"spec.storageClusters[%d].name: duplicate value %q", i, storageCluster.Name,
+			))
+		}
+		names[storageCluster.Name] = true
+	}
+
+	if count := managedOCS.Spec.StorageDeviceSetCount; count != nil {
+		if (v.MinStorageDeviceSetCount > 0 && *count < v.MinStorageDeviceSetCount) ||
+			(v.MaxStorageDeviceSetCount > 0 && *count > v.MaxStorageDeviceSetCount) {
+			v.Log.Info("Rejecting ManagedOCS with an out of range storage device set count",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageDeviceSetCount", *count)
+			return admission.Denied(fmt.Sprintf(
+				"spec.storageDeviceSetCount: value %d is out of range, it must be between %d and %d",
+				*count, v.MinStorageDeviceSetCount, v.MaxStorageDeviceSetCount,
+			))
+		}
+	}
+
+	// The storage profiles set the storage device set count, which would conflict with an
+	// explicit or an auto-sized count
+	if profile := managedOCS.Spec.StorageProfile; profile != "" && profile != v1.StorageProfileCustom &&
+		(managedOCS.Spec.StorageDeviceSetCount != nil || managedOCS.Spec.AutoSizing) {
+		v.Log.Info("Rejecting ManagedOCS combining a storage profile with a storage device set count",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageProfile", profile)
+		return admission.Denied(fmt.Sprintf(
+			"spec.storageProfile: profile %q cannot be combined with spec.storageDeviceSetCount or spec.autoSizing, "+
+				"use the %q profile instead", profile, v1.StorageProfileCustom,
+		))
+	}
+
+	if isEncryptionEnabled(managedOCS) && !isValidKMSEndpoint(managedOCS.Spec.EncryptionConfig.KMSEndpoint) {
+		endpoint := managedOCS.Spec.EncryptionConfig.KMSEndpoint
+		v.Log.Info("Rejecting ManagedOCS with an invalid KMS endpoint",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace, "kmsEndpoint", endpoint)
+		return admission.Denied(fmt.Sprintf(
+			"spec.encryptionConfig.kmsEndpoint: invalid value %q, it must be an http or https URL", endpoint,
+		))
+	}
+
+	for i, toleration := range managedOCS.Spec.Tolerations {
+		if !isKnownTaintEffect(toleration.Effect) {
+			v.Log.Info("Rejecting ManagedOCS with an unknown toleration effect",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "effect", toleration.Effect)
+			return admission.Denied(fmt.Sprintf(
+				"spec.tolerations[%d].effect: unsupported value %q, supported values are %q",
+				i, toleration.Effect, knownTaintEffects,
+			))
+		}
+	}
+
+	// Network selectors only apply to provider networks, OCS fails to start with host networking
+	if network := managedOCS.Spec.NetworkSpec; network != nil && network.Provider == hostNetworkProvider && len(network.Selectors) > 0 {
+		v.Log.Info("Rejecting ManagedOCS combining host networking with a provider network",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+		return admission.Denied(fmt.Sprintf(
+			"spec.networkSpec: selectors cannot be set with the %q provider", hostNetworkProvider,
+		))
+	}
+
+	// The passthrough spec is merged into the StorageCluster spec, so it must be an object
+	if cephClusterSpec := managedOCS.Spec.CephClusterSpec; cephClusterSpec != nil && len(cephClusterSpec.Raw) > 0 {
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(cephClusterSpec.Raw, &fields); err != nil {
+			v.Log.Info("Rejecting ManagedOCS with an invalid ceph cluster spec",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+			return admission.Denied(fmt.Sprintf("spec.cephClusterSpec: invalid value, it must be a JSON object: %v", err))
+		}
+	}
+
+	if window := managedOCS.Spec.MaintenanceWindow; window != nil && !window.End.After(window.Start.Time) {
+		v.Log.Info("Rejecting ManagedOCS with a maintenance window ending before it starts",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+		return admission.Denied("spec.maintenanceWindow.end: the maintenance window must end after it starts")
+	}
+
+	if soakDuration := managedOCS.Spec.MigrationSoakDuration; soakDuration != nil && soakDuration.Duration <= 0 {
+		v.Log.Info("Rejecting ManagedOCS with a non-positive migration soak duration",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace, "migrationSoakDuration", soakDuration.Duration)
+		return admission.Denied("spec.migrationSoakDuration: the migration soak duration must be positive")
+	}
+
+	if reason := validateOCSVersionRange(managedOCS.Spec.MinOCSVersion, managedOCS.Spec.MaxOCSVersion); reason != "" {
+		v.Log.Info("Rejecting ManagedOCS with an invalid OCS version range",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace,
+			"minOCSVersion", managedOCS.Spec.MinOCSVersion, "maxOCSVersion", managedOCS.Spec.MaxOCSVersion)
+		return admission.Denied(reason)
+	}
+
+	for key := range managedOCS.Spec.StorageClusterAnnotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			v.Log.Info("Rejecting ManagedOCS with an invalid storage cluster annotation",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "annotation", key)
+			return admission.Denied(fmt.Sprintf(
+				"spec.storageClusterAnnotations: invalid key %q: %s", key, strings.Join(errs, ", "),
+			))
+		}
+	}
+
+	if reason := validateStorageClusterLabels(managedOCS.Spec.StorageClusterLabels); reason != "" {
+		v.Log.Info("Rejecting ManagedOCS with an invalid storage cluster label",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+		return admission.Denied(reason)
+	}
+
+	if reason := validateImageOverrides(managedOCS.Spec.ImageOverrides); reason != "" {
+		v.Log.Info("Rejecting ManagedOCS with an invalid image override",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+		return admission.Denied(reason)
+	}
+
+	// Any Ceph option can be set through the custom Ceph configuration, it has to be acknowledged
+	if len(managedOCS.Spec.CustomCephConf) > 0 && !managedOCS.Spec.AllowCustomCephConf {
+		v.Log.Info("Rejecting ManagedOCS setting a custom Ceph configuration without allowing it",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+		return admission.Denied("spec.customCephConf: spec.allowCustomCephConf must be set to apply a custom Ceph configuration")
+	}
+	if reason := validateCustomCephConf(managedOCS.Spec.CustomCephConf); reason != "" {
+		v.Log.Info("Rejecting ManagedOCS with an invalid custom Ceph configuration",
+			"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+		return admission.Denied(reason)
+	}
+
+	if ref := managedOCS.Spec.ExternalCephSecretRef; ref != nil && v.Client != nil {
+		reason, err := v.validateExternalCephSecret(ctx, managedOCS.Namespace, ref.Name)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if reason != "" {
+			v.Log.Info("Rejecting ManagedOCS with an invalid external Ceph secret",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "secret", ref.Name)
+			return admission.Denied(reason)
+		}
+	}
+
+	// Checks against the previous state of an updated ManagedOCS
+	if req.Operation == admissionv1beta1.Update && len(req.OldObject.Raw) > 0 {
+		oldManagedOCS := &v1.ManagedOCS{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldManagedOCS); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// Renaming the storage cluster would leave the current one behind
+		if oldName := oldManagedOCS.Spec.StorageClusterName; oldName != "" && oldName != managedOCS.Spec.StorageClusterName {
+			v.Log.Info("Rejecting ManagedOCS renaming its storage cluster",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageClusterName", oldName)
+			return admission.Denied("spec.storageClusterName: the storage cluster name cannot be changed once set")
+		}
+		// OSDs cannot be decrypted in place, so encryption cannot be turned off once enabled
+		if isEncryptionEnabled(oldManagedOCS) && !isEncryptionEnabled(managedOCS) {
+			v.Log.Info("Rejecting ManagedOCS disabling encryption",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace)
+			return admission.Denied("spec.encryptionConfig.enabled: encryption cannot be disabled once enabled")
+		}
+		// Removing storage device sets would lose the data of their OSDs
+		if oldCount, count := oldManagedOCS.Spec.StorageDeviceSetCount, managedOCS.Spec.StorageDeviceSetCount; oldCount != nil && count != nil && *count < *oldCount {
+			v.Log.Info("Rejecting ManagedOCS decreasing its storage device set count",
+				"name", managedOCS.Name, "namespace", managedOCS.Namespace, "storageDeviceSetCount", *count)
+			return admission.Denied(fmt.Sprintf(
+				"spec.storageDeviceSetCount: value %d is lower than the current value %d, the count cannot be decreased",
+				*count, *oldCount,
+			))
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+// validateStorageClusterLabels checks that the storage cluster labels are valid and not
+// reserved. It returns the reason of the denial, if any
+func validateStorageClusterLabels(labels map[string]string) string {
+	for key, value := range labels {
+		for _, prefix := range reservedLabelPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return fmt.Sprintf("spec.storageClusterLabels: key %q is reserved, labels prefixed with %q cannot be set", key, reservedLabelPrefixes)
+			}
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Sprintf("spec.storageClusterLabels: invalid key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Sprintf("spec.storageClusterLabels[%s]: invalid value %q: %s", key, value, strings.Join(errs, ", "))
+		}
+	}
+	return ""
+}
+
+// validateImageOverrides checks that the image overrides are set for known components, with
+// non-empty images. It returns the reason of the denial, if any
+func validateImageOverrides(overrides map[string]string) string {
+	for component, image := range overrides {
+		if !utils.Contains(imageOverrideComponents, component) {
+			return fmt.Sprintf("spec.imageOverrides: unknown component %q, it must be one of %q", component, imageOverrideComponents)
+		}
+		if strings.TrimSpace(image) == "" {
+			return fmt.Sprintf("spec.imageOverrides[%s]: the image cannot be empty", component)
+		}
+	}
+	return ""
+}
+
+// validateCustomCephConf checks that the custom Ceph configuration options are keyed in the
+// section/option format, with single line values. It returns the reason of the denial, if any
+func validateCustomCephConf(customCephConf map[string]string) string {
+	for key, value := range customCephConf {
+		if _, _, ok := utils.ParseCephConfKey(key); !ok {
+			return fmt.Sprintf("spec.customCephConf: invalid key %q, it must be in the section/option format", key)
+		}
+		if !utils.IsValidCephConfValue(value) {
+			return fmt.Sprintf("spec.customCephConf[%s]: the value cannot span several lines", key)
+		}
+	}
+	return ""
+}
+
+// validateExternalCephSecret checks that the external Ceph secret exists and holds all the
+// required keys. It returns the reason of the denial, if any
+func (v *ManagedOCSValidator) validateExternalCephSecret(ctx context.Context, namespace, name string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("spec.externalCephSecretRef.name: secret %q not found", name), nil
+		}
+		return "", fmt.Errorf("unable to get external Ceph secret %v: %w", name, err)
+	}
+	missing := []string{}
+	for _, key := range externalCephSecretKeys {
+		if _, ok := secret.Data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("spec.externalCephSecretRef.name: secret %q is missing the %q keys", name, missing), nil
+	}
+	return "", nil
+}
+
+// InjectDecoder injects the decoder into the validator
+func (v *ManagedOCSValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func isKnownReconcileStrategy(strategy v1.ReconcileStrategy) bool {
+	for _, known := range knownReconcileStrategies {
+		if strings.EqualFold(string(strategy), string(known)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownTaintEffect checks the effect of a toleration, where an empty effect matches all the
+// taint effects
+func isKnownTaintEffect(effect corev1.TaintEffect) bool {
+	if effect == "" {
+		return true
+	}
+	for _, known := range knownTaintEffects {
+		if effect == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isEncryptionEnabled(managedOCS *v1.ManagedOCS) bool {
+	return managedOCS.Spec.EncryptionConfig != nil && managedOCS.Spec.EncryptionConfig.Enabled
+}
+
+func isValidKMSEndpoint(endpoint string) bool {
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validateOCSVersionRange returns the reason the OCS version bounds are invalid, or an empty
+// string if they are valid
+func validateOCSVersionRange(minVersion string, maxVersion string) string {
+	var min, max semver.Version
+	var err error
+	if minVersion != "" {
+		if min, err = semver.ParseTolerant(minVersion); err != nil {
+			return fmt.Sprintf("spec.minOCSVersion: invalid value %q, it must be a semantic version", minVersion)
+		}
+	}
+	if maxVersion != "" {
+		if max, err = semver.ParseTolerant(maxVersion); err != nil {
+			return fmt.Sprintf("spec.maxOCSVersion: invalid value %q, it must be a semantic version", maxVersion)
+		}
+	}
+	if minVersion != "" && maxVersion != "" && min.GT(max) {
+		return fmt.Sprintf("spec.minOCSVersion: value %q is greater than spec.maxOCSVersion %q", minVersion, maxVersion)
+	}
+	return ""
+}
@@ -0,0 +1,540 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	rook "github.com/rook/rook/pkg/apis/rook.io/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/openshift/ocs-osd-deployer/api/v1alpha1"
+)
+
+func newManagedOCSRequest(op admissionv1beta1.Operation, managedOCS *v1.ManagedOCS) admission.Request {
+	raw, err := json.Marshal(managedOCS)
+	Expect(err).ToNot(HaveOccurred())
+
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: op,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func newExternalCephSecret() *corev1.Secret {
+	secret := &corev1.Secret{}
+	secret.Name = "external-ceph"
+	secret.Namespace = "primary"
+	secret.Data = map[string][]byte{}
+	for _, key := range externalCephSecretKeys {
+		secret.Data[key] = []byte(key)
+	}
+	return secret
+}
+
+func newManagedOCSUpdateRequest(oldManagedOCS, managedOCS *v1.ManagedOCS) admission.Request {
+	req := newManagedOCSRequest(admissionv1beta1.Update, managedOCS)
+	raw, err := json.Marshal(oldManagedOCS)
+	Expect(err).ToNot(HaveOccurred())
+	req.OldObject = runtime.RawExtension{Raw: raw}
+	return req
+}
+
+// secretClient serves a single secret, other calls are not expected
+type secretClient struct {
+	client.Client
+	secret *corev1.Secret
+}
+
+func (c *secretClient) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if secret, ok := obj.(*corev1.Secret); ok && key.Name == c.secret.Name && key.Namespace == c.secret.Namespace {
+		c.secret.DeepCopyInto(secret)
+		return nil
+	}
+	return errors.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+var _ = Describe("ManagedOCSValidator", func() {
+	ctx := context.Background()
+
+	var validator *ManagedOCSValidator
+	var managedOCS *v1.ManagedOCS
+
+	BeforeEach(func() {
+		validator = &ManagedOCSValidator{
+			Log:                      ctrl.Log.WithName("test"),
+			MinStorageDeviceSetCount: 1,
+			MaxStorageDeviceSetCount: 10,
+		}
+		Expect(validator.InjectDecoder(testDecoder)).Should(Succeed())
+
+		managedOCS = &v1.ManagedOCS{}
+		managedOCS.APIVersion = v1.GroupVersion.String()
+		managedOCS.Kind = "ManagedOCS"
+		managedOCS.Name = "managedocs"
+		managedOCS.Namespace = "primary"
+	})
+
+	When("the reconcile strategy is not set", func() {
+		It("should allow the request", func() {
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the reconcile strategy is set to a known value", func() {
+		It("should allow the request", func() {
+			for _, strategy := range []v1.ReconcileStrategy{"none", "strict", "force", "Strict"} {
+				managedOCS.Spec.ReconcileStrategy = strategy
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Update, managedOCS))
+				Expect(resp.Allowed).Should(BeTrue(), "strategy %q", strategy)
+			}
+		})
+	})
+	When("the reconcile strategy is set to an unknown value", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.ReconcileStrategy = "lenient"
+			for _, op := range []admissionv1beta1.Operation{admissionv1beta1.Create, admissionv1beta1.Update} {
+				resp := validator.Handle(ctx, newManagedOCSRequest(op, managedOCS))
+				Expect(resp.Allowed).Should(BeFalse())
+				Expect(resp.Result).ShouldNot(BeNil())
+				Expect(string(resp.Result.Reason)).Should(ContainSubstring("lenient"))
+			}
+		})
+	})
+	When("the storage device set count is within the allowed range", func() {
+		It("should allow the request", func() {
+			for _, count := range []int32{1, 5, 10} {
+				managedOCS.Spec.StorageDeviceSetCount = &count
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+				Expect(resp.Allowed).Should(BeTrue(), "count %d", count)
+			}
+		})
+	})
+	When("the storage device set count is outside of the allowed range", func() {
+		It("should deny the request with a reason", func() {
+			for _, count := range []int32{0, 11} {
+				managedOCS.Spec.StorageDeviceSetCount = &count
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Update, managedOCS))
+				Expect(resp.Allowed).Should(BeFalse(), "count %d", count)
+				Expect(string(resp.Result.Reason)).Should(ContainSubstring("storageDeviceSetCount"))
+			}
+		})
+	})
+	When("the storage device set count is increased", func() {
+		It("should allow the request", func() {
+			oldManagedOCS := managedOCS.DeepCopy()
+			oldCount, count := int32(3), int32(4)
+			oldManagedOCS.Spec.StorageDeviceSetCount = &oldCount
+			managedOCS.Spec.StorageDeviceSetCount = &count
+			resp := validator.Handle(ctx, newManagedOCSUpdateRequest(oldManagedOCS, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the storage device set count is decreased", func() {
+		It("should deny the request with a reason", func() {
+			oldManagedOCS := managedOCS.DeepCopy()
+			oldCount, count := int32(4), int32(3)
+			oldManagedOCS.Spec.StorageDeviceSetCount = &oldCount
+			managedOCS.Spec.StorageDeviceSetCount = &count
+			resp := validator.Handle(ctx, newManagedOCSUpdateRequest(oldManagedOCS, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("cannot be decreased"))
+		})
+	})
+	When("a storage profile is set without a storage device set count", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.StorageProfile = v1.StorageProfileMedium
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("a storage profile is combined with a storage device set count or auto sizing", func() {
+		It("should deny the request with a reason", func() {
+			count := int32(3)
+			managedOCS.Spec.StorageProfile = v1.StorageProfileLarge
+			managedOCS.Spec.StorageDeviceSetCount = &count
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.storageProfile"))
+
+			managedOCS.Spec.StorageDeviceSetCount = nil
+			managedOCS.Spec.AutoSizing = true
+			resp = validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+		})
+	})
+	When("the custom storage profile is combined with a storage device set count", func() {
+		It("should allow the request", func() {
+			count := int32(3)
+			managedOCS.Spec.StorageProfile = v1.StorageProfileCustom
+			managedOCS.Spec.StorageDeviceSetCount = &count
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("encryption is enabled with a valid KMS endpoint", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.EncryptionConfig = &v1.EncryptionConfig{
+				Enabled:     true,
+				KMSEndpoint: "https://vault.example.com:8200",
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("encryption is enabled with an invalid KMS endpoint", func() {
+		It("should deny the request with a reason", func() {
+			for _, endpoint := range []string{"", "vault.example.com", "ftp://vault.example.com", "https://"} {
+				managedOCS.Spec.EncryptionConfig = &v1.EncryptionConfig{Enabled: true, KMSEndpoint: endpoint}
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+				Expect(resp.Allowed).Should(BeFalse(), "endpoint %q", endpoint)
+				Expect(string(resp.Result.Reason)).Should(ContainSubstring("kmsEndpoint"))
+			}
+		})
+	})
+	When("encryption is disabled with an invalid KMS endpoint", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.EncryptionConfig = &v1.EncryptionConfig{KMSEndpoint: "vault.example.com"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("encryption is disabled after being enabled", func() {
+		It("should deny the request with a reason", func() {
+			oldManagedOCS := managedOCS.DeepCopy()
+			oldManagedOCS.Spec.EncryptionConfig = &v1.EncryptionConfig{
+				Enabled:     true,
+				KMSEndpoint: "https://vault.example.com:8200",
+			}
+			for _, encryption := range []*v1.EncryptionConfig{nil, {KMSEndpoint: "https://vault.example.com:8200"}} {
+				managedOCS.Spec.EncryptionConfig = encryption
+				resp := validator.Handle(ctx, newManagedOCSUpdateRequest(oldManagedOCS, managedOCS))
+				Expect(resp.Allowed).Should(BeFalse())
+				Expect(string(resp.Result.Reason)).Should(ContainSubstring("encryption cannot be disabled"))
+			}
+		})
+	})
+	When("encryption is enabled after being disabled", func() {
+		It("should allow the request", func() {
+			oldManagedOCS := managedOCS.DeepCopy()
+			managedOCS.Spec.EncryptionConfig = &v1.EncryptionConfig{
+				Enabled:     true,
+				KMSEndpoint: "https://vault.example.com:8200",
+			}
+			resp := validator.Handle(ctx, newManagedOCSUpdateRequest(oldManagedOCS, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the storage cluster name is a valid DNS subdomain", func() {
+		It("should allow the request", func() {
+			for _, name := range []string{"ocs-storagecluster", "test.storagecluster"} {
+				managedOCS.Spec.StorageClusterName = name
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+				Expect(resp.Allowed).Should(BeTrue(), "name %q", name)
+			}
+		})
+	})
+	When("the storage cluster name is not a valid DNS subdomain", func() {
+		It("should deny the request with a reason", func() {
+			for _, name := range []string{"OCS-StorageCluster", "ocs_storagecluster", "-ocs"} {
+				managedOCS.Spec.StorageClusterName = name
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+				Expect(resp.Allowed).Should(BeFalse(), "name %q", name)
+				Expect(string(resp.Result.Reason)).Should(ContainSubstring("storageClusterName"))
+			}
+		})
+	})
+	When("the storage cluster name is changed", func() {
+		It("should deny the request with a reason", func() {
+			oldManagedOCS := managedOCS.DeepCopy()
+			oldManagedOCS.Spec.StorageClusterName = "ocs-storagecluster"
+			managedOCS.Spec.StorageClusterName = "test-storagecluster"
+			resp := validator.Handle(ctx, newManagedOCSUpdateRequest(oldManagedOCS, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("cannot be changed"))
+		})
+	})
+	When("the storage cluster name is set for the first time", func() {
+		It("should allow the request", func() {
+			oldManagedOCS := managedOCS.DeepCopy()
+			managedOCS.Spec.StorageClusterName = "ocs-storagecluster"
+			resp := validator.Handle(ctx, newManagedOCSUpdateRequest(oldManagedOCS, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the OCS version range is valid", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.MinOCSVersion = "4.7"
+			managedOCS.Spec.MaxOCSVersion = "v4.8.0"
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("an OCS version bound is not a semantic version", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.MaxOCSVersion = "latest"
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("maxOCSVersion"))
+		})
+	})
+	When("the minimum OCS version is greater than the maximum", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.MinOCSVersion = "4.8"
+			managedOCS.Spec.MaxOCSVersion = "4.7"
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("greater than"))
+		})
+	})
+	When("the tolerations have known effects", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.Tolerations = []corev1.Toleration{
+				{Key: "node.ocs.openshift.io/storage", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node.ocs.openshift.io/storage", Operator: corev1.TolerationOpExists},
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("a toleration has an unknown effect", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.Tolerations = []corev1.Toleration{
+				{Key: "node.ocs.openshift.io/storage", Value: "true", Effect: "NoEffect"},
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.tolerations[0].effect"))
+		})
+	})
+	When("the network spec uses a multus provider network", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.NetworkSpec = &rook.NetworkSpec{
+				Provider:  "multus",
+				Selectors: map[string]string{"public": "openshift-storage/public-net"},
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the network spec combines host networking with network selectors", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.NetworkSpec = &rook.NetworkSpec{
+				Provider:  "host",
+				Selectors: map[string]string{"public": "openshift-storage/public-net"},
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.networkSpec"))
+		})
+	})
+	When("the ceph cluster spec is a JSON object", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.CephClusterSpec = &runtime.RawExtension{Raw: []byte(`{"externalStorage":{"enable":true}}`)}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the ceph cluster spec is not a JSON object", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.CephClusterSpec = &runtime.RawExtension{Raw: []byte(`["externalStorage"]`)}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.cephClusterSpec"))
+		})
+	})
+	When("the maintenance window ends after it starts", func() {
+		It("should allow the request", func() {
+			start := metav1.NewTime(time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC))
+			managedOCS.Spec.MaintenanceWindow = &v1.MaintenanceWindow{
+				Start: start,
+				End:   metav1.NewTime(start.Add(2 * time.Hour)),
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the maintenance window ends before it starts", func() {
+		It("should deny the request with a reason", func() {
+			start := metav1.NewTime(time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC))
+			managedOCS.Spec.MaintenanceWindow = &v1.MaintenanceWindow{
+				Start: start,
+				End:   metav1.NewTime(start.Add(-2 * time.Hour)),
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.maintenanceWindow.end"))
+		})
+	})
+	When("the image overrides are set for known components", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.ImageOverrides = map[string]string{
+				v1.ImageOverrideCeph: "registry.local/ceph/ceph:v15",
+				v1.ImageOverrideRook: "registry.local/rook/ceph:v1.5",
+			}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("an image override is set for an unknown component", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.ImageOverrides = map[string]string{"grafana": "registry.local/grafana:latest"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.imageOverrides"))
+		})
+	})
+	When("an image override has an empty image", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.ImageOverrides = map[string]string{v1.ImageOverrideNooBaa: ""}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.imageOverrides[noobaa]"))
+		})
+	})
+	When("a custom Ceph configuration is set without allowing it", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.CustomCephConf = map[string]string{"osd/osd_scrub_max_interval": "604800"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.allowCustomCephConf"))
+
+			managedOCS.Spec.AllowCustomCephConf = true
+			resp = validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("a custom Ceph configuration key is not in the section/option format", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.AllowCustomCephConf = true
+			managedOCS.Spec.CustomCephConf = map[string]string{"osd_scrub_max_interval": "604800"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.customCephConf"))
+		})
+	})
+	When("the migration soak duration is not positive", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.MigrateFromExisting = true
+			managedOCS.Spec.MigrationSoakDuration = &metav1.Duration{}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.migrationSoakDuration"))
+		})
+	})
+	When("the storage cluster annotations have valid keys", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.StorageClusterAnnotations = map[string]string{"cluster.ocs.openshift.io/local-devices": "true"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("a storage cluster annotation has an invalid key", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.StorageClusterAnnotations = map[string]string{"local devices": "true"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.storageClusterAnnotations"))
+		})
+	})
+	When("the storage cluster labels are valid", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.StorageClusterLabels = map[string]string{"cost-center": "storage", "app.kubernetes.io/part-of": "ocs"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("a storage cluster label is reserved or invalid", func() {
+		It("should deny the request with a reason", func() {
+			for _, labels := range []map[string]string{
+				{"kubernetes.io/hostname": "node"},
+				{"openshift.io/cluster-monitoring": "true"},
+				{"cost-center": "not a label value"},
+			} {
+				managedOCS.Spec.StorageClusterLabels = labels
+				resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+				Expect(resp.Allowed).Should(BeFalse(), "labels %v", labels)
+				Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.storageClusterLabels"))
+			}
+		})
+	})
+	When("the external Ceph secret holds all the credentials", func() {
+		It("should allow the request", func() {
+			validator.Client = &secretClient{secret: newExternalCephSecret()}
+			managedOCS.Spec.ExternalCephSecretRef = &corev1.LocalObjectReference{Name: "external-ceph"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("the external Ceph secret lacks credentials", func() {
+		It("should deny the request with a reason", func() {
+			secret := newExternalCephSecret()
+			delete(secret.Data, "adminKey")
+			validator.Client = &secretClient{secret: secret}
+			managedOCS.Spec.ExternalCephSecretRef = &corev1.LocalObjectReference{Name: "external-ceph"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("adminKey"))
+		})
+	})
+	When("the external Ceph secret does not exist", func() {
+		It("should deny the request with a reason", func() {
+			validator.Client = &secretClient{secret: newExternalCephSecret()}
+			managedOCS.Spec.ExternalCephSecretRef = &corev1.LocalObjectReference{Name: "missing"}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.externalCephSecretRef"))
+		})
+	})
+	When("the storage clusters have unique valid names", func() {
+		It("should allow the request", func() {
+			managedOCS.Spec.StorageClusters = []v1.ManagedStorageCluster{{Name: "metadata"}, {Name: "data"}}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
+	When("a storage cluster name is not a valid DNS subdomain", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.StorageClusters = []v1.ManagedStorageCluster{{Name: "metadata"}, {Name: "Data"}}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("spec.storageClusters[1].name"))
+		})
+	})
+	When("storage cluster names are duplicated", func() {
+		It("should deny the request with a reason", func() {
+			managedOCS.Spec.StorageClusters = []v1.ManagedStorageCluster{{Name: "data"}, {Name: "data"}}
+			resp := validator.Handle(ctx, newManagedOCSRequest(admissionv1beta1.Create, managedOCS))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Reason)).Should(ContainSubstring("duplicate"))
+		})
+	})
+})